@@ -0,0 +1,256 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Mist3rBru/go-clack/core/utils"
+	"github.com/Mist3rBru/go-clack/core/validator"
+)
+
+type AsyncSelectPrompt[TValue comparable] struct {
+	Prompt[TValue]
+	Options  []*SelectOption[TValue]
+	Search   string
+	Required bool
+	// Load returns the options for the search, and is cancelled once the search changes again
+	Load func(ctx context.Context, query string) ([]*SelectOption[TValue], error)
+	// Debounce is the delay between the last search change and the loading of its options
+	Debounce        time.Duration
+	IsLoading       bool
+	LoadingDuration time.Duration
+	LoadError       string
+
+	cancelLoad context.CancelFunc
+	runCtx     context.Context
+}
+
+type AsyncSelectPromptParams[TValue comparable] struct {
+	Input        io.Reader
+	Output       io.Writer
+	InitialValue TValue
+	Load         func(ctx context.Context, query string) ([]*SelectOption[TValue], error)
+	Debounce     time.Duration
+	Required     bool
+	Render       func(p *AsyncSelectPrompt[TValue]) string
+}
+
+// NewAsyncSelectPrompt creates a select prompt whose options are loaded for the search as it is typed,
+// 300ms after the last change by default.
+// The options are first loaded once the prompt runs, and the loads are cancelled once the run returns.
+func NewAsyncSelectPrompt[TValue comparable](params AsyncSelectPromptParams[TValue]) *AsyncSelectPrompt[TValue] {
+	v := validator.NewValidator("AsyncSelectPrompt")
+	v.ValidateRender(params.Render)
+
+	if params.Load == nil {
+		v.Panic("Load is required")
+	}
+	if params.Debounce == 0 {
+		params.Debounce = 300 * time.Millisecond
+	}
+
+	var p AsyncSelectPrompt[TValue]
+	p = AsyncSelectPrompt[TValue]{
+		Prompt: *NewPrompt(PromptParams[TValue]{
+			Input:        params.Input,
+			Output:       params.Output,
+			InitialValue: params.InitialValue,
+			Validate:     WrapValidate[TValue](nil, &p.Required, "Please select an option."),
+			ParseAnswer:  p.handleAnswer,
+			Render:       WrapRender[TValue](&p, params.Render),
+		}),
+		Required: params.Required,
+		Load:     params.Load,
+		Debounce: params.Debounce,
+		// The options are loading until the first load after the prompt runs
+		IsLoading: true,
+	}
+	p.onRun = func(ctx context.Context) {
+		p.runCtx = ctx
+		p.load(0)
+	}
+
+	p.On(KeyEvent, func(args ...any) {
+		p.handleKeyPress(args[0].(*Key))
+	})
+
+	p.On(PasteEvent, func(args ...any) {
+		p.Search, _ = p.TrackPasteValue(args[0].(string), p.Search, utils.GraphemeCount(p.Search), false)
+		p.load(p.Debounce)
+	})
+
+	p.On(FinalizeEvent, func(args ...any) {
+		p.stopLoad()
+		p.IsLoading = false
+	})
+
+	return &p
+}
+
+func (p *AsyncSelectPrompt[TValue]) handleKeyPress(key *Key) {
+	search := p.Search
+
+	switch key.Name {
+	case UpKey, LeftKey:
+		p.CursorIndex = utils.MinMaxIndex(p.CursorIndex-1, len(p.Options))
+	case DownKey, RightKey:
+		p.CursorIndex = utils.MinMaxIndex(p.CursorIndex+1, len(p.Options))
+	case HomeKey:
+		p.CursorIndex = 0
+	case EndKey:
+		p.CursorIndex = max(len(p.Options)-1, 0)
+	case EnterKey, CancelKey:
+	default:
+		p.Search, _ = p.TrackKeyValue(key, p.Search, utils.GraphemeCount(p.Search))
+	}
+
+	p.changeValue()
+
+	if p.Search != search {
+		p.load(p.Debounce)
+	}
+}
+
+func (p *AsyncSelectPrompt[TValue]) changeValue() {
+	if p.CursorIndex >= 0 && p.CursorIndex < len(p.Options) {
+		p.Value = p.Options[p.CursorIndex].Value
+		return
+	}
+
+	p.Value = *new(TValue)
+}
+
+// load cancels the previous loading, and loads the options for the search after the delay,
+// rendering the loading state until they are loaded.
+func (p *AsyncSelectPrompt[TValue]) load(delay time.Duration) {
+	p.stopLoad()
+	ctx, cancel := context.WithCancel(p.loadContext())
+	p.cancelLoad = cancel
+	p.IsLoading = true
+	p.LoadingDuration = 0
+	p.LoadError = ""
+	search := p.Search
+
+	go func() {
+		loadingStart := time.Now()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(125 * time.Millisecond):
+				p.update(ctx, func() {
+					p.LoadingDuration = time.Since(loadingStart)
+				})
+			}
+		}
+	}()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		options, err := p.Load(ctx, search)
+		p.update(ctx, func() {
+			cancel()
+			p.setOptions(options, err)
+		})
+	}()
+}
+
+// stopLoad cancels the loading in progress, if any.
+func (p *AsyncSelectPrompt[TValue]) stopLoad() {
+	if p.cancelLoad != nil {
+		p.cancelLoad()
+	}
+}
+
+// loadContext returns the context of the prompt's run, which the loads derive from,
+// or the background context for answers submitted without running the prompt.
+func (p *AsyncSelectPrompt[TValue]) loadContext() context.Context {
+	if p.runCtx != nil {
+		return p.runCtx
+	}
+	return context.Background()
+}
+
+// update applies a change of the loading, unless it was cancelled, and renders it.
+// It holds the prompt lock, as the loading runs apart from the key presses and renders.
+func (p *AsyncSelectPrompt[TValue]) update(ctx context.Context, change func()) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if ctx.Err() != nil {
+		return
+	}
+	change()
+	p.renderLoad()
+}
+
+// Loading reports whether the options are loading, and is safe to call while they are.
+func (p *AsyncSelectPrompt[TValue]) Loading() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.IsLoading
+}
+
+// setOptions replaces the options with the loaded ones, keeping the cursor on the selected value if it is still listed.
+func (p *AsyncSelectPrompt[TValue]) setOptions(options []*SelectOption[TValue], err error) {
+	p.IsLoading = false
+	if err != nil {
+		p.LoadError = err.Error()
+		options = nil
+	}
+
+	p.Options = options
+	p.CursorIndex = 0
+	for i, option := range options {
+		if value, ok := any(option.Value).(string); ok && value == "" {
+			option.Value = any(option.Label).(TValue)
+		}
+		if option.Value == p.Value {
+			p.CursorIndex = i
+		}
+	}
+	p.changeValue()
+}
+
+// renderLoad renders the loading state, once the prompt is rendered and while it is not finished.
+func (p *AsyncSelectPrompt[TValue]) renderLoad() {
+	if p.Frame == "" || p.State == SubmitState || p.State == CancelState {
+		return
+	}
+	if p.State == InitialState {
+		p.State = ActiveState
+	}
+	p.render()
+}
+
+func (p *AsyncSelectPrompt[TValue]) handleAnswer(answer string) error {
+	if answer == "" {
+		return nil
+	}
+
+	p.stopLoad()
+
+	p.Search = answer
+	options, err := p.Load(p.loadContext(), answer)
+	p.setOptions(options, err)
+	if err != nil {
+		return err
+	}
+
+	for i, option := range p.Options {
+		if matchAnswer(answer, option.Label, option.Value) {
+			p.CursorIndex = i
+			p.Value = option.Value
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Invalid option: %s", answer)
+}
@@ -0,0 +1,184 @@
+package core_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mist3rBru/go-clack/core"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var fruits = []string{"apple", "apricot", "banana", "cherry"}
+
+func loadFruits(ctx context.Context, query string) ([]*core.SelectOption[string], error) {
+	var options []*core.SelectOption[string]
+	for _, fruit := range fruits {
+		if strings.HasPrefix(fruit, query) {
+			options = append(options, &core.SelectOption[string]{Label: fruit})
+		}
+	}
+	return options, nil
+}
+
+func newAsyncSelectPrompt(params core.AsyncSelectPromptParams[string]) *core.AsyncSelectPrompt[string] {
+	if params.Load == nil {
+		params.Load = loadFruits
+	}
+	params.Debounce = time.Millisecond
+	params.Render = func(p *core.AsyncSelectPrompt[string]) string { return "" }
+	return core.NewAsyncSelectPrompt(params)
+}
+
+// runAsyncSelectPrompt runs the prompt on an input without keys until the test ends.
+func runAsyncSelectPrompt(t *testing.T, params core.AsyncSelectPromptParams[string]) *core.AsyncSelectPrompt[string] {
+	input, writer := io.Pipe()
+	params.Input = input
+	params.Output = &bytes.Buffer{}
+	p := newAsyncSelectPrompt(params)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		p.RunContext(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+		writer.Close()
+	})
+
+	return p
+}
+
+func waitLoaded(t *testing.T, p *core.AsyncSelectPrompt[string]) {
+	assert.Eventually(t, func() bool {
+		return !p.Loading()
+	}, time.Second, time.Millisecond)
+}
+
+func TestAsyncSelectPromptWithoutLoad(t *testing.T) {
+	assert.Panics(t, func() {
+		core.NewAsyncSelectPrompt(core.AsyncSelectPromptParams[string]{
+			Render: func(p *core.AsyncSelectPrompt[string]) string { return "" },
+		})
+	})
+}
+
+func TestAsyncSelectPromptInitialLoad(t *testing.T) {
+	p := runAsyncSelectPrompt(t, core.AsyncSelectPromptParams[string]{InitialValue: "banana"})
+
+	waitLoaded(t, p)
+	assert.Len(t, p.Options, 4)
+	assert.Equal(t, 2, p.CursorIndex)
+	assert.Equal(t, "banana", p.Value)
+}
+
+func TestAsyncSelectPromptSearch(t *testing.T) {
+	p := runAsyncSelectPrompt(t, core.AsyncSelectPromptParams[string]{})
+	waitLoaded(t, p)
+
+	p.PressKey(&core.Key{Char: "a"})
+	p.PressKey(&core.Key{Char: "p"})
+	assert.Equal(t, "ap", p.Search)
+	assert.True(t, p.Loading())
+
+	waitLoaded(t, p)
+	assert.Len(t, p.Options, 2)
+
+	p.PressKey(&core.Key{Name: core.DownKey})
+	assert.Equal(t, "apricot", p.Value)
+
+	p.PressKey(&core.Key{Name: core.EnterKey})
+	assert.Equal(t, core.SubmitState, p.State)
+}
+
+func TestAsyncSelectPromptCancelPreviousLoad(t *testing.T) {
+	cancelled := make(chan string, 1)
+	p := runAsyncSelectPrompt(t, core.AsyncSelectPromptParams[string]{
+		Load: func(ctx context.Context, query string) ([]*core.SelectOption[string], error) {
+			if query == "a" {
+				<-ctx.Done()
+				cancelled <- query
+				return nil, ctx.Err()
+			}
+			return loadFruits(ctx, query)
+		},
+	})
+	waitLoaded(t, p)
+
+	p.PressKey(&core.Key{Char: "a"})
+	time.Sleep(10 * time.Millisecond)
+	p.PressKey(&core.Key{Char: "p"})
+
+	assert.Equal(t, "a", <-cancelled)
+	waitLoaded(t, p)
+	assert.Equal(t, "", p.LoadError)
+	assert.Len(t, p.Options, 2)
+}
+
+func TestAsyncSelectPromptLoadError(t *testing.T) {
+	p := runAsyncSelectPrompt(t, core.AsyncSelectPromptParams[string]{
+		Load: func(ctx context.Context, query string) ([]*core.SelectOption[string], error) {
+			return nil, errors.New("registry is down")
+		},
+		Required: true,
+	})
+	waitLoaded(t, p)
+
+	assert.Equal(t, "registry is down", p.LoadError)
+	assert.Empty(t, p.Options)
+
+	p.PressKey(&core.Key{Name: core.EnterKey})
+	assert.Equal(t, core.ErrorState, p.State)
+	assert.Equal(t, "Please select an option.", p.Error)
+}
+
+func TestAsyncSelectPromptLoadOnRun(t *testing.T) {
+	loads := make(chan context.Context, 1)
+	params := core.AsyncSelectPromptParams[string]{
+		Load: func(ctx context.Context, query string) ([]*core.SelectOption[string], error) {
+			loads <- ctx
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+	newAsyncSelectPrompt(params)
+	select {
+	case <-loads:
+		t.Fatal("the options were loaded before the prompt ran")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	params.Input, _ = io.Pipe()
+	params.Output = &bytes.Buffer{}
+	p := newAsyncSelectPrompt(params)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := p.RunContext(ctx)
+		done <- err
+	}()
+
+	loadCtx := <-loads
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+	<-loadCtx.Done()
+}
+
+func TestAsyncSelectPromptSubmitAnswer(t *testing.T) {
+	p := newAsyncSelectPrompt(core.AsyncSelectPromptParams[string]{})
+
+	value, err := p.SubmitAnswer("cherry")
+	assert.NoError(t, err)
+	assert.Equal(t, "cherry", value)
+
+	_, err = p.SubmitAnswer("grape")
+	assert.ErrorContains(t, err, "Invalid option: grape")
+}
@@ -0,0 +1,160 @@
+package core
+
+import (
+	"io"
+	"strings"
+
+	"github.com/Mist3rBru/go-clack/core/utils"
+	"github.com/Mist3rBru/go-clack/core/validator"
+	"github.com/Mist3rBru/go-clack/third_party/picocolors"
+)
+
+// Suggestion is a candidate value of an AutocompletePrompt.
+type Suggestion struct {
+	Value       string
+	Description string
+}
+
+type AutocompletePrompt struct {
+	Prompt[string]
+	Placeholder string
+	Required    bool
+	// Suggest returns the candidates for the typed input, in the order they are listed
+	Suggest     func(input string) []Suggestion
+	Suggestions []Suggestion
+	// SuggestionIndex is the highlighted suggestion, or -1 if none is
+	SuggestionIndex int
+	// Hint is the rest of the highlighted, or first, suggestion that starts with the typed input
+	Hint string
+}
+
+type AutocompletePromptParams struct {
+	Input        io.Reader
+	Output       io.Writer
+	InitialValue string
+	Placeholder  string
+	Required     bool
+	Suggest      func(input string) []Suggestion
+	Validate     func(value string) error
+	Render       func(p *AutocompletePrompt) string
+}
+
+// NewAutocompletePrompt creates a text prompt that lists the suggestions for the typed input.
+// Tab and Shift+Tab highlight the next and previous suggestions, Right accepts the hint,
+// and Enter submits the highlighted suggestion, or the typed text if none is highlighted.
+func NewAutocompletePrompt(params AutocompletePromptParams) *AutocompletePrompt {
+	v := validator.NewValidator("AutocompletePrompt")
+	v.ValidateRender(params.Render)
+
+	if params.Suggest == nil {
+		v.Panic("Suggest is required")
+	}
+
+	var p AutocompletePrompt
+	p = AutocompletePrompt{
+		Prompt: *NewPrompt(PromptParams[string]{
+			Input:        params.Input,
+			Output:       params.Output,
+			InitialValue: params.InitialValue,
+			CursorIndex:  utils.GraphemeCount(params.InitialValue),
+			Validate:     WrapValidate(params.Validate, &p.Required, "Value is required! Please enter a value."),
+			Render:       WrapRender[string](&p, params.Render),
+		}),
+		Placeholder:     params.Placeholder,
+		Required:        params.Required,
+		Suggest:         params.Suggest,
+		SuggestionIndex: -1,
+	}
+	p.changeSuggestions()
+
+	p.On(KeyEvent, func(args ...any) {
+		p.handleKeyPress(args[0].(*Key))
+	})
+
+	p.On(PasteEvent, func(args ...any) {
+		p.Value, p.CursorIndex = p.TrackPasteValue(args[0].(string), p.Value, p.CursorIndex, false)
+		p.changeSuggestions()
+	})
+
+	return &p
+}
+
+func (p *AutocompletePrompt) handleKeyPress(key *Key) {
+	switch key.Name {
+	case TabKey, UpKey, DownKey:
+		if len(p.Suggestions) == 0 {
+			if key.Name == TabKey && p.Value == "" && p.Placeholder != "" {
+				p.complete(p.Placeholder)
+			}
+			return
+		}
+		isBackward := key.Name == UpKey || (key.Name == TabKey && key.Shift)
+		if len(p.Suggestions) == 1 && !isBackward {
+			p.complete(p.Suggestions[0].Value)
+		} else if isBackward {
+			p.highlight(utils.MinMaxIndex(p.SuggestionIndex-1, len(p.Suggestions)))
+		} else {
+			p.highlight(utils.MinMaxIndex(p.SuggestionIndex+1, len(p.Suggestions)))
+		}
+	case EnterKey:
+		if p.SuggestionIndex >= 0 {
+			p.Value = p.Suggestions[p.SuggestionIndex].Value
+			p.CursorIndex = utils.GraphemeCount(p.Value)
+		}
+	case RightKey:
+		if p.CursorIndex >= utils.GraphemeCount(p.Value) && p.Hint != "" {
+			p.complete(p.Value + p.Hint)
+			return
+		}
+		p.Value, p.CursorIndex = p.TrackKeyValue(key, p.Value, p.CursorIndex)
+	default:
+		value := p.Value
+		p.Value, p.CursorIndex = p.TrackKeyValue(key, p.Value, p.CursorIndex)
+		if p.Value != value {
+			p.changeSuggestions()
+		}
+	}
+}
+
+// complete sets the value, moving the cursor to its end, and lists the suggestions for it.
+func (p *AutocompletePrompt) complete(value string) {
+	p.Value = value
+	p.CursorIndex = utils.GraphemeCount(value)
+	p.changeSuggestions()
+}
+
+// changeSuggestions lists the suggestions for the value, with none highlighted.
+func (p *AutocompletePrompt) changeSuggestions() {
+	p.Suggestions = p.Suggest(p.Value)
+	p.highlight(-1)
+}
+
+func (p *AutocompletePrompt) highlight(index int) {
+	p.SuggestionIndex = index
+	p.Hint = ""
+
+	if len(p.Suggestions) == 0 {
+		return
+	}
+	suggestion := p.Suggestions[max(index, 0)]
+	if p.Value != "" && strings.HasPrefix(suggestion.Value, p.Value) {
+		p.Hint = strings.TrimPrefix(suggestion.Value, p.Value)
+	}
+}
+
+// ValueWithCursor returns the value with the cursor, followed by the dimmed hint.
+func (p *AutocompletePrompt) ValueWithCursor() string {
+	if p.CursorIndex < utils.GraphemeCount(p.Value) {
+		return ValueWithCursor(p.Value, p.CursorIndex) + picocolors.Dim(p.Hint)
+	}
+	if p.Hint == "" {
+		return p.Value + picocolors.Inverse(" ")
+	}
+	hint := utils.Graphemes(p.Hint)
+	return p.Value + picocolors.Inverse(hint[0]) + picocolors.Dim(strings.Join(hint[1:], ""))
+}
+
+// LimitSuggestions limits the suggestion lines to fit within the terminal size, keeping the highlighted one in view.
+func (p *AutocompletePrompt) LimitSuggestions(lines []string, usedLines int) string {
+	return p.limitLines(lines, usedLines, max(p.SuggestionIndex, 0))
+}
@@ -0,0 +1,123 @@
+package core_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Mist3rBru/go-clack/core"
+	"github.com/Mist3rBru/go-clack/third_party/picocolors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var colors = []core.Suggestion{
+	{Value: "blue", Description: "The sky"},
+	{Value: "green", Description: "The grass"},
+	{Value: "grey"},
+}
+
+func suggestColors(input string) []core.Suggestion {
+	var suggestions []core.Suggestion
+	for _, color := range colors {
+		if strings.HasPrefix(color.Value, input) {
+			suggestions = append(suggestions, color)
+		}
+	}
+	return suggestions
+}
+
+func newAutocompletePrompt(params core.AutocompletePromptParams) *core.AutocompletePrompt {
+	params.Suggest = suggestColors
+	params.Render = func(p *core.AutocompletePrompt) string { return "" }
+	return core.NewAutocompletePrompt(params)
+}
+
+func TestAutocompletePromptWithoutSuggest(t *testing.T) {
+	assert.Panics(t, func() {
+		core.NewAutocompletePrompt(core.AutocompletePromptParams{
+			Render: func(p *core.AutocompletePrompt) string { return "" },
+		})
+	})
+}
+
+func TestAutocompletePromptSuggestions(t *testing.T) {
+	p := newAutocompletePrompt(core.AutocompletePromptParams{})
+	assert.Len(t, p.Suggestions, 3)
+	assert.Equal(t, "", p.Hint)
+
+	p.PressKey(&core.Key{Char: "g"})
+	assert.Equal(t, colors[1:], p.Suggestions)
+	assert.Equal(t, -1, p.SuggestionIndex)
+	assert.Equal(t, "reen", p.Hint)
+	assert.Equal(t, "g"+picocolors.Inverse("r")+picocolors.Dim("een"), p.ValueWithCursor())
+
+	p.PressKey(&core.Key{Char: "x"})
+	assert.Empty(t, p.Suggestions)
+	assert.Equal(t, "", p.Hint)
+}
+
+func TestAutocompletePromptTabCycling(t *testing.T) {
+	p := newAutocompletePrompt(core.AutocompletePromptParams{InitialValue: "gr"})
+
+	p.PressKey(&core.Key{Name: core.TabKey})
+	assert.Equal(t, 0, p.SuggestionIndex)
+	assert.Equal(t, "een", p.Hint)
+
+	p.PressKey(&core.Key{Name: core.TabKey})
+	assert.Equal(t, 1, p.SuggestionIndex)
+	assert.Equal(t, "ey", p.Hint)
+
+	p.PressKey(&core.Key{Name: core.TabKey})
+	assert.Equal(t, 0, p.SuggestionIndex)
+
+	p.PressKey(&core.Key{Name: core.TabKey, Shift: true})
+	assert.Equal(t, 1, p.SuggestionIndex)
+	assert.Equal(t, "gr", p.Value)
+}
+
+func TestAutocompletePromptTabCompleteSingleSuggestion(t *testing.T) {
+	p := newAutocompletePrompt(core.AutocompletePromptParams{InitialValue: "b"})
+
+	p.PressKey(&core.Key{Name: core.TabKey})
+
+	assert.Equal(t, "blue", p.Value)
+	assert.Equal(t, 4, p.CursorIndex)
+}
+
+func TestAutocompletePromptAcceptHint(t *testing.T) {
+	p := newAutocompletePrompt(core.AutocompletePromptParams{InitialValue: "gre"})
+
+	p.PressKey(&core.Key{Name: core.RightKey})
+
+	assert.Equal(t, "green", p.Value)
+	assert.Equal(t, 5, p.CursorIndex)
+}
+
+func TestAutocompletePromptSubmitSuggestion(t *testing.T) {
+	p := newAutocompletePrompt(core.AutocompletePromptParams{InitialValue: "gr"})
+
+	p.PressKey(&core.Key{Name: core.DownKey})
+	p.PressKey(&core.Key{Name: core.DownKey})
+	p.PressKey(&core.Key{Name: core.EnterKey})
+
+	assert.Equal(t, core.SubmitState, p.State)
+	assert.Equal(t, "grey", p.Value)
+}
+
+func TestAutocompletePromptSubmitFreeText(t *testing.T) {
+	p := newAutocompletePrompt(core.AutocompletePromptParams{InitialValue: "purple"})
+
+	p.PressKey(&core.Key{Name: core.EnterKey})
+
+	assert.Equal(t, core.SubmitState, p.State)
+	assert.Equal(t, "purple", p.Value)
+}
+
+func TestAutocompletePromptRequired(t *testing.T) {
+	p := newAutocompletePrompt(core.AutocompletePromptParams{Required: true})
+
+	p.PressKey(&core.Key{Name: core.EnterKey})
+
+	assert.Equal(t, core.ErrorState, p.State)
+	assert.Equal(t, "Value is required! Please enter a value.", p.Error)
+}
@@ -1,7 +1,9 @@
 package core
 
 import (
-	"os"
+	"fmt"
+	"io"
+	"strings"
 
 	"github.com/Mist3rBru/go-clack/core/utils"
 	"github.com/Mist3rBru/go-clack/core/validator"
@@ -14,8 +16,8 @@ type ConfirmPrompt struct {
 }
 
 type ConfirmPromptParams struct {
-	Input        *os.File
-	Output       *os.File
+	Input        io.Reader
+	Output       io.Writer
 	Active       string
 	Inactive     string
 	InitialValue bool
@@ -39,6 +41,7 @@ func NewConfirmPrompt(params ConfirmPromptParams) *ConfirmPrompt {
 			Input:        params.Input,
 			Output:       params.Output,
 			InitialValue: params.InitialValue,
+			ParseAnswer:  p.handleAnswer,
 			Render:       WrapRender[bool](&p, params.Render),
 		}),
 		Active:   params.Active,
@@ -59,3 +62,16 @@ func (p *ConfirmPrompt) handleKeyPress(key *Key) {
 		p.Value = !p.Value
 	}
 }
+
+func (p *ConfirmPrompt) handleAnswer(answer string) error {
+	switch {
+	case answer == "":
+	case strings.EqualFold(answer, p.Active) || strings.EqualFold(answer, "y") || strings.EqualFold(answer, "yes") || strings.EqualFold(answer, "true"):
+		p.Value = true
+	case strings.EqualFold(answer, p.Inactive) || strings.EqualFold(answer, "n") || strings.EqualFold(answer, "no") || strings.EqualFold(answer, "false"):
+		p.Value = false
+	default:
+		return fmt.Errorf("Please answer %s or %s.", p.Active, p.Inactive)
+	}
+	return nil
+}
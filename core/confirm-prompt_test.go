@@ -1,6 +1,7 @@
 package core_test
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/Mist3rBru/go-clack/core"
@@ -33,3 +34,41 @@ func TestChangeConfirmValue(t *testing.T) {
 	assert.Equal(t, false, p.Value)
 	assert.Equal(t, 0, p.CursorIndex)
 }
+
+func TestConfirmLineAnswer(t *testing.T) {
+	testCases := []struct {
+		answer   string
+		expected bool
+	}{
+		{answer: "y", expected: true},
+		{answer: "Yes", expected: true},
+		{answer: "n", expected: false},
+		{answer: "NO", expected: false},
+		{answer: "", expected: true},
+	}
+
+	for _, tC := range testCases {
+		p := core.NewConfirmPrompt(core.ConfirmPromptParams{
+			Input:        NewPipeInput(tC.answer + "\n"),
+			Output:       &bytes.Buffer{},
+			InitialValue: true,
+			Render:       func(p *core.ConfirmPrompt) string { return "" },
+		})
+
+		value, err := p.Run()
+		assert.NoError(t, err)
+		assert.Equal(t, tC.expected, value, tC.answer)
+	}
+}
+
+func TestConfirmInvalidLineAnswer(t *testing.T) {
+	p := core.NewConfirmPrompt(core.ConfirmPromptParams{
+		Input:  NewPipeInput("maybe\n"),
+		Output: &bytes.Buffer{},
+		Render: func(p *core.ConfirmPrompt) string { return "" },
+	})
+
+	_, err := p.Run()
+	assert.ErrorIs(t, err, core.ErrInputExhausted)
+	assert.Equal(t, "Please answer yes or no.", p.Error)
+}
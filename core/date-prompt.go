@@ -0,0 +1,350 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Mist3rBru/go-clack/core/validator"
+	"github.com/Mist3rBru/go-clack/third_party/picocolors"
+)
+
+// DateSegment is the part of the date being edited with the arrow keys.
+type DateSegment int
+
+const (
+	// DaySegment moves the selected day on the calendar
+	DaySegment DateSegment = iota
+	// HourSegment changes the hour of the time editor
+	HourSegment
+	// MinuteSegment changes the minute of the time editor
+	MinuteSegment
+)
+
+type DatePrompt struct {
+	Prompt[time.Time]
+	// Text is the typed date, parsed with Layout
+	Text       string
+	Layout     string
+	Min        time.Time
+	Max        time.Time
+	IsDisabled func(date time.Time) bool
+	WithTime   bool
+	WeekStart  time.Weekday
+	Segment    DateSegment
+}
+
+type DatePromptParams struct {
+	Input        io.Reader
+	Output       io.Writer
+	InitialValue time.Time
+	Layout       string
+	Min          time.Time
+	Max          time.Time
+	IsDisabled   func(date time.Time) bool
+	WithTime     bool
+	WeekStart    time.Weekday
+	Validate     func(value time.Time) error
+	Render       func(p *DatePrompt) string
+}
+
+func NewDatePrompt(params DatePromptParams) *DatePrompt {
+	v := validator.NewValidator("DatePrompt")
+	v.ValidateRender(params.Render)
+
+	if !params.Min.IsZero() && !params.Max.IsZero() && params.Min.After(params.Max) {
+		v.Panic("Min must be before Max")
+	}
+	if params.Layout == "" {
+		params.Layout = time.DateOnly
+		if params.WithTime {
+			params.Layout = "2006-01-02 15:04"
+		}
+	}
+
+	initialValue := params.InitialValue
+	if initialValue.IsZero() {
+		initialValue = time.Now()
+	}
+	if params.WithTime {
+		initialValue = initialValue.Truncate(time.Minute)
+	} else {
+		initialValue = startOfDay(initialValue)
+	}
+
+	var p DatePrompt
+	p = DatePrompt{
+		Prompt: *NewPrompt(PromptParams[time.Time]{
+			Input:        params.Input,
+			Output:       params.Output,
+			InitialValue: initialValue,
+			ParseAnswer:  p.handleAnswer,
+			Render:       WrapRender[time.Time](&p, params.Render),
+		}),
+		Layout:     params.Layout,
+		Min:        params.Min,
+		Max:        params.Max,
+		IsDisabled: params.IsDisabled,
+		WithTime:   params.WithTime,
+		WeekStart:  params.WeekStart,
+	}
+	p.Validate = func(value time.Time) error {
+		if err := p.validate(value); err != nil {
+			return err
+		}
+		if params.Validate != nil {
+			return params.Validate(value)
+		}
+		return nil
+	}
+	p.moveTo(initialValue, 1)
+
+	p.On(KeyEvent, func(args ...any) {
+		p.handleKeyPress(args[0].(*Key))
+	})
+
+	p.On(PasteEvent, func(args ...any) {
+		p.Text, p.CursorIndex = p.TrackPasteValue(args[0].(string), p.Text, p.CursorIndex, false)
+		p.parseText()
+	})
+
+	return &p
+}
+
+func (p *DatePrompt) handleKeyPress(key *Key) {
+	if p.Text != "" {
+		switch key.Name {
+		case LeftKey, RightKey, HomeKey, EndKey, BackspaceKey, DeleteKey, SpaceKey:
+			p.Text, p.CursorIndex = p.TrackKeyValue(key, p.Text, p.CursorIndex)
+			p.parseText()
+			return
+		case UpKey, DownKey, PageUpKey, PageDownKey:
+			p.Text = ""
+			p.CursorIndex = 0
+		}
+	}
+
+	if key.Name == TabKey && p.WithTime && !key.Shift {
+		p.Segment = (p.Segment + 1) % 3
+		return
+	}
+
+	if p.Segment != DaySegment {
+		p.handleTimeKeyPress(key)
+		return
+	}
+
+	switch key.Name {
+	case LeftKey:
+		p.moveTo(p.Value.AddDate(0, 0, -1), -1)
+	case RightKey:
+		p.moveTo(p.Value.AddDate(0, 0, 1), 1)
+	case UpKey:
+		p.moveTo(p.Value.AddDate(0, 0, -7), -1)
+	case DownKey:
+		p.moveTo(p.Value.AddDate(0, 0, 7), 1)
+	case PageUpKey:
+		p.moveTo(addMonths(p.Value, -1), -1)
+	case PageDownKey:
+		p.moveTo(addMonths(p.Value, 1), 1)
+	case HomeKey:
+		p.moveTo(p.Value.AddDate(0, 0, 1-p.Value.Day()), 1)
+	case EndKey:
+		p.moveTo(addMonths(p.Value.AddDate(0, 0, 1-p.Value.Day()), 1).AddDate(0, 0, -1), -1)
+	default:
+		if key.Char != "" && !key.Ctrl && !key.Meta {
+			p.Text, p.CursorIndex = p.TrackKeyValue(key, p.Text, p.CursorIndex)
+			p.parseText()
+		}
+	}
+}
+
+func (p *DatePrompt) handleTimeKeyPress(key *Key) {
+	unit := time.Hour
+	if p.Segment == MinuteSegment {
+		unit = time.Minute
+	}
+
+	switch key.Name {
+	case UpKey:
+		p.setTime(p.Value.Add(unit))
+	case DownKey:
+		p.setTime(p.Value.Add(-unit))
+	case LeftKey, RightKey:
+		if p.Segment == HourSegment {
+			p.Segment = MinuteSegment
+		} else {
+			p.Segment = HourSegment
+		}
+	}
+}
+
+// setTime sets the time of the selected day, wrapping around midnight without changing the day.
+func (p *DatePrompt) setTime(t time.Time) {
+	day := startOfDay(p.Value)
+	p.Value = day.Add(time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute)
+}
+
+// parseText updates the value from the typed date, if it matches the layout.
+func (p *DatePrompt) parseText() {
+	if date, err := time.ParseInLocation(p.Layout, p.Text, p.Value.Location()); err == nil {
+		p.Value = date
+	}
+}
+
+// moveTo selects the date, clamped by Min and Max, or the closest available date in the given direction.
+// The selection is kept if there is no available date in that direction.
+func (p *DatePrompt) moveTo(date time.Time, direction int) {
+	if !p.Min.IsZero() && date.Before(startOfDay(p.Min)) {
+		date = date.AddDate(0, 0, daysBetween(date, p.Min))
+	}
+	if !p.Max.IsZero() && startOfDay(date).After(startOfDay(p.Max)) {
+		date = date.AddDate(0, 0, -daysBetween(p.Max, date))
+	}
+
+	for range 366 {
+		if p.IsAvailable(date) {
+			p.Value = date
+			return
+		}
+		date = date.AddDate(0, 0, direction)
+		if !p.isInRange(date) {
+			return
+		}
+	}
+}
+
+// IsAvailable reports whether the date's day is within Min and Max, and not disabled.
+func (p *DatePrompt) IsAvailable(date time.Time) bool {
+	return p.isInRange(date) && (p.IsDisabled == nil || !p.IsDisabled(startOfDay(date)))
+}
+
+func (p *DatePrompt) isInRange(date time.Time) bool {
+	day := startOfDay(date)
+	return (p.Min.IsZero() || !day.Before(startOfDay(p.Min))) && (p.Max.IsZero() || !day.After(startOfDay(p.Max)))
+}
+
+func (p *DatePrompt) validate(value time.Time) error {
+	if p.Text != "" {
+		if _, err := time.ParseInLocation(p.Layout, p.Text, value.Location()); err != nil {
+			return fmt.Errorf("Please enter a valid date, like %s.", value.Format(p.Layout))
+		}
+	}
+
+	// Without the time editor, dates are compared by day
+	minValue, maxValue := p.Min, p.Max
+	if !p.WithTime {
+		value, minValue, maxValue = startOfDay(value), startOfDay(minValue), startOfDay(maxValue)
+	}
+
+	switch {
+	case !p.Min.IsZero() && !p.Max.IsZero() && (value.Before(minValue) || value.After(maxValue)):
+		return fmt.Errorf("Please select a date from %s to %s.", p.Min.Format(p.Layout), p.Max.Format(p.Layout))
+	case !p.Min.IsZero() && value.Before(minValue):
+		return fmt.Errorf("Please select a date on or after %s.", p.Min.Format(p.Layout))
+	case !p.Max.IsZero() && value.After(maxValue):
+		return fmt.Errorf("Please select a date on or before %s.", p.Max.Format(p.Layout))
+	case p.IsDisabled != nil && p.IsDisabled(startOfDay(value)):
+		return errors.New("This date is not available.")
+	}
+	return nil
+}
+
+func (p *DatePrompt) handleAnswer(answer string) error {
+	if answer == "" {
+		return nil
+	}
+
+	date, err := time.ParseInLocation(p.Layout, answer, p.Value.Location())
+	if err != nil {
+		if date, err = time.Parse(time.RFC3339, answer); err != nil {
+			return fmt.Errorf("Please enter a valid date, like %s.", p.Value.Format(p.Layout))
+		}
+	}
+
+	p.Text = ""
+	p.Value = date
+	return nil
+}
+
+// FormattedValue returns the selected date formatted with the layout.
+func (p *DatePrompt) FormattedValue() string {
+	return p.Value.Format(p.Layout)
+}
+
+// ValueWithCursor returns the typed date with the cursor, or the selected date followed by the cursor, ready to be typed over.
+func (p *DatePrompt) ValueWithCursor() string {
+	if p.Text != "" {
+		return ValueWithCursor(p.Text, p.CursorIndex)
+	}
+	if p.Segment != DaySegment {
+		return p.FormattedValue()
+	}
+	return p.FormattedValue() + picocolors.Inverse(" ")
+}
+
+// TimeWithCursor returns the selected time, highlighting the segment being edited.
+func (p *DatePrompt) TimeWithCursor() string {
+	hour, minute := p.Value.Format("15"), p.Value.Format("04")
+	switch p.Segment {
+	case HourSegment:
+		hour = picocolors.Inverse(hour)
+	case MinuteSegment:
+		minute = picocolors.Inverse(minute)
+	}
+	return hour + ":" + minute
+}
+
+// Calendar formats the month grid of the selected date: a line for the month, one for the weekdays and one per week.
+// Each day cell is styled by the style function, if any, e.g. to highlight the selected date or dim unavailable ones.
+func (p *DatePrompt) Calendar(style func(date time.Time, cell string) string, options FormatLinesOptions) string {
+	if style == nil {
+		style = func(date time.Time, cell string) string { return cell }
+	}
+
+	first := startOfDay(p.Value.AddDate(0, 0, 1-p.Value.Day()))
+	lines := []string{first.Format("January 2006")}
+
+	weekdays := make([]string, 7)
+	for i := range weekdays {
+		weekdays[i] = time.Weekday((int(p.WeekStart) + i) % 7).String()[:2]
+	}
+	lines = append(lines, strings.Join(weekdays, " "))
+
+	offset := (int(first.Weekday()) - int(p.WeekStart) + 7) % 7
+	cells := make([]string, offset, 7)
+	for i := range cells {
+		cells[i] = "  "
+	}
+	for date := first; date.Month() == first.Month(); date = date.AddDate(0, 0, 1) {
+		cells = append(cells, style(date, fmt.Sprintf("%2d", date.Day())))
+		if len(cells) == 7 {
+			lines = append(lines, strings.Join(cells, " "))
+			cells = cells[:0]
+		}
+	}
+	if len(cells) > 0 {
+		lines = append(lines, strings.Join(cells, " "))
+	}
+
+	return p.FormatLines(lines, options)
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// addMonths adds months to the date, keeping its day within the target month.
+func addMonths(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(t.Day(), lastDay)-1)
+}
+
+// daysBetween returns the number of calendar days from a to b.
+func daysBetween(a, b time.Time) int {
+	a, b = startOfDay(a), time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, a.Location())
+	return int(b.Sub(a).Round(24*time.Hour) / (24 * time.Hour))
+}
@@ -0,0 +1,230 @@
+package core_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mist3rBru/go-clack/core"
+	"github.com/Mist3rBru/go-clack/third_party/picocolors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newDatePrompt(params core.DatePromptParams) *core.DatePrompt {
+	params.Render = func(p *core.DatePrompt) string { return "" }
+	return core.NewDatePrompt(params)
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestDatePromptInitialValue(t *testing.T) {
+	p := newDatePrompt(core.DatePromptParams{InitialValue: time.Date(2024, 5, 15, 10, 30, 0, 0, time.UTC)})
+
+	assert.Equal(t, date(2024, 5, 15), p.Value)
+	assert.Equal(t, "2024-05-15", p.FormattedValue())
+}
+
+func TestDatePromptInitialValueWithTime(t *testing.T) {
+	p := newDatePrompt(core.DatePromptParams{
+		InitialValue: time.Date(2024, 5, 15, 10, 30, 45, 0, time.UTC),
+		WithTime:     true,
+	})
+
+	assert.Equal(t, time.Date(2024, 5, 15, 10, 30, 0, 0, time.UTC), p.Value)
+	assert.Equal(t, "2024-05-15 10:30", p.FormattedValue())
+}
+
+func TestDatePromptInvalidParams(t *testing.T) {
+	assert.Panics(t, func() {
+		newDatePrompt(core.DatePromptParams{Min: date(2024, 5, 2), Max: date(2024, 5, 1)})
+	})
+}
+
+func TestDatePromptNavigation(t *testing.T) {
+	p := newDatePrompt(core.DatePromptParams{InitialValue: date(2024, 1, 31)})
+
+	p.PressKey(&core.Key{Name: core.RightKey})
+	assert.Equal(t, date(2024, 2, 1), p.Value)
+
+	p.PressKey(&core.Key{Name: core.LeftKey})
+	assert.Equal(t, date(2024, 1, 31), p.Value)
+
+	p.PressKey(&core.Key{Name: core.UpKey})
+	assert.Equal(t, date(2024, 1, 24), p.Value)
+
+	p.PressKey(&core.Key{Name: core.DownKey})
+	assert.Equal(t, date(2024, 1, 31), p.Value)
+
+	p.PressKey(&core.Key{Name: core.PageDownKey})
+	assert.Equal(t, date(2024, 2, 29), p.Value)
+
+	p.PressKey(&core.Key{Name: core.PageUpKey})
+	assert.Equal(t, date(2024, 1, 29), p.Value)
+
+	p.PressKey(&core.Key{Name: core.HomeKey})
+	assert.Equal(t, date(2024, 1, 1), p.Value)
+
+	p.PressKey(&core.Key{Name: core.EndKey})
+	assert.Equal(t, date(2024, 1, 31), p.Value)
+}
+
+func TestDatePromptNavigationLimits(t *testing.T) {
+	p := newDatePrompt(core.DatePromptParams{
+		InitialValue: date(2024, 5, 10),
+		Min:          date(2024, 5, 8),
+		Max:          date(2024, 5, 20),
+	})
+
+	p.PressKey(&core.Key{Name: core.UpKey})
+	assert.Equal(t, date(2024, 5, 8), p.Value)
+
+	p.PressKey(&core.Key{Name: core.PageDownKey})
+	assert.Equal(t, date(2024, 5, 20), p.Value)
+
+	p.PressKey(&core.Key{Name: core.RightKey})
+	assert.Equal(t, date(2024, 5, 20), p.Value)
+}
+
+func TestDatePromptSkipDisabledDates(t *testing.T) {
+	isWeekend := func(date time.Time) bool {
+		return date.Weekday() == time.Saturday || date.Weekday() == time.Sunday
+	}
+	p := newDatePrompt(core.DatePromptParams{
+		InitialValue: date(2024, 5, 4),
+		IsDisabled:   isWeekend,
+	})
+	assert.Equal(t, date(2024, 5, 6), p.Value)
+
+	p.PressKey(&core.Key{Name: core.LeftKey})
+	assert.Equal(t, date(2024, 5, 3), p.Value)
+
+	p.PressKey(&core.Key{Name: core.RightKey})
+	assert.Equal(t, date(2024, 5, 6), p.Value)
+
+	assert.False(t, p.IsAvailable(date(2024, 5, 5)))
+	assert.True(t, p.IsAvailable(date(2024, 5, 7)))
+}
+
+func TestDatePromptTypeDate(t *testing.T) {
+	p := newDatePrompt(core.DatePromptParams{
+		InitialValue: date(2024, 5, 15),
+		Layout:       "02/01/2006",
+	})
+
+	for _, char := range "24/12/2025" {
+		p.PressKey(&core.Key{Char: string(char), Name: core.KeyName(char)})
+	}
+	assert.Equal(t, "24/12/2025", p.Text)
+	assert.Equal(t, date(2025, 12, 24), p.Value)
+
+	p.PressKey(&core.Key{Name: core.BackspaceKey})
+	p.PressKey(&core.Key{Name: core.EnterKey})
+	assert.Equal(t, core.ErrorState, p.State)
+	assert.Equal(t, "Please enter a valid date, like 24/12/2025.", p.Error)
+
+	p.PressKey(&core.Key{Name: core.DownKey})
+	assert.Equal(t, "", p.Text)
+	assert.Equal(t, date(2025, 12, 31), p.Value)
+}
+
+func TestDatePromptTimeSegments(t *testing.T) {
+	p := newDatePrompt(core.DatePromptParams{
+		InitialValue: time.Date(2024, 5, 15, 23, 30, 0, 0, time.UTC),
+		WithTime:     true,
+	})
+
+	p.PressKey(&core.Key{Name: core.TabKey})
+	assert.Equal(t, core.HourSegment, p.Segment)
+	assert.Equal(t, picocolors.Inverse("23")+":30", p.TimeWithCursor())
+
+	p.PressKey(&core.Key{Name: core.UpKey})
+	assert.Equal(t, time.Date(2024, 5, 15, 0, 30, 0, 0, time.UTC), p.Value)
+
+	p.PressKey(&core.Key{Name: core.RightKey})
+	assert.Equal(t, core.MinuteSegment, p.Segment)
+
+	p.PressKey(&core.Key{Name: core.DownKey})
+	assert.Equal(t, time.Date(2024, 5, 15, 0, 29, 0, 0, time.UTC), p.Value)
+
+	p.PressKey(&core.Key{Name: core.TabKey})
+	assert.Equal(t, core.DaySegment, p.Segment)
+
+	p.PressKey(&core.Key{Name: core.RightKey})
+	assert.Equal(t, time.Date(2024, 5, 16, 0, 29, 0, 0, time.UTC), p.Value)
+}
+
+func TestDatePromptValidate(t *testing.T) {
+	p := newDatePrompt(core.DatePromptParams{
+		InitialValue: date(2024, 5, 15),
+		Min:          date(2024, 5, 1),
+		Max:          date(2024, 5, 31),
+	})
+
+	p.Value = date(2024, 6, 1)
+	p.PressKey(&core.Key{Name: core.EnterKey})
+	assert.Equal(t, "Please select a date from 2024-05-01 to 2024-05-31.", p.Error)
+
+	p.Value = date(2024, 5, 31)
+	p.PressKey(&core.Key{Name: core.EnterKey})
+	assert.Equal(t, core.SubmitState, p.State)
+}
+
+func TestDatePromptCalendar(t *testing.T) {
+	p := newDatePrompt(core.DatePromptParams{InitialValue: date(2024, 5, 15)})
+
+	calendar := p.Calendar(func(date time.Time, cell string) string {
+		if date.Equal(p.Value) {
+			return "[" + strings.TrimSpace(cell) + "]"
+		}
+		return cell
+	}, core.FormatLinesOptions{})
+
+	expected := strings.Join([]string{
+		"May 2024",
+		"Su Mo Tu We Th Fr Sa",
+		"          1  2  3  4",
+		" 5  6  7  8  9 10 11",
+		"12 13 14 [15] 16 17 18",
+		"19 20 21 22 23 24 25",
+		"26 27 28 29 30 31",
+	}, "\r\n")
+	assert.Equal(t, expected, calendar)
+}
+
+func TestDatePromptCalendarWeekStart(t *testing.T) {
+	p := newDatePrompt(core.DatePromptParams{
+		InitialValue: date(2024, 5, 15),
+		WeekStart:    time.Monday,
+	})
+
+	lines := strings.Split(p.Calendar(nil, core.FormatLinesOptions{}), "\r\n")
+
+	assert.Equal(t, "Mo Tu We Th Fr Sa Su", lines[1])
+	assert.Equal(t, "       1  2  3  4  5", lines[2])
+	assert.Equal(t, "27 28 29 30 31", lines[6])
+}
+
+func TestDatePromptSubmitAnswer(t *testing.T) {
+	p := core.NewDatePrompt(core.DatePromptParams{
+		Output:       &bytes.Buffer{},
+		InitialValue: date(2024, 5, 15),
+		IsDisabled: func(date time.Time) bool {
+			return date.Day() == 1
+		},
+		Render: func(p *core.DatePrompt) string { return p.FormattedValue() },
+	})
+
+	value, err := p.SubmitAnswer("2024-06-10")
+	assert.NoError(t, err)
+	assert.Equal(t, date(2024, 6, 10), value)
+
+	_, err = p.SubmitAnswer("June 10")
+	assert.ErrorContains(t, err, "Please enter a valid date, like 2024-06-10.")
+
+	_, err = p.SubmitAnswer("2024-07-01")
+	assert.ErrorContains(t, err, "This date is not available.")
+}
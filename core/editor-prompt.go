@@ -0,0 +1,174 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/Mist3rBru/go-clack/core/validator"
+)
+
+type EditorPrompt struct {
+	Prompt[string]
+	// Editor is the command that edits the file, defaulting to $VISUAL, then $EDITOR
+	Editor        string
+	Extension     string
+	CommentPrefix string
+	Required      bool
+
+	editErr error
+}
+
+type EditorPromptParams struct {
+	Input         io.Reader
+	Output        io.Writer
+	InitialValue  string
+	Editor        string
+	Extension     string
+	CommentPrefix string
+	Required      bool
+	Validate      func(value string) error
+	Render        func(p *EditorPrompt) string
+}
+
+// NewEditorPrompt creates a prompt that opens the value in an external editor on Enter,
+// submitting the edited content without its comment lines, which start with `#` by default.
+func NewEditorPrompt(params EditorPromptParams) *EditorPrompt {
+	v := validator.NewValidator("EditorPrompt")
+	v.ValidateRender(params.Render)
+
+	if params.Editor == "" {
+		params.Editor = defaultEditor()
+	}
+	if params.CommentPrefix == "" {
+		params.CommentPrefix = "#"
+	}
+	if params.Extension != "" && !strings.HasPrefix(params.Extension, ".") {
+		params.Extension = "." + params.Extension
+	}
+
+	var p EditorPrompt
+	validate := WrapValidate(params.Validate, &p.Required, "Value is required! Please enter a value.")
+	validateEdit := func(value string) error {
+		// An editor failure is shown like a validation error, so the prompt is not submitted
+		if p.editErr != nil {
+			return p.editErr
+		}
+		return validate(value)
+	}
+	p = EditorPrompt{
+		Prompt: *NewPrompt(PromptParams[string]{
+			Input:        params.Input,
+			Output:       params.Output,
+			InitialValue: params.InitialValue,
+			Validate:     validateEdit,
+			Render:       WrapRender[string](&p, params.Render),
+		}),
+		Editor:        params.Editor,
+		Extension:     params.Extension,
+		CommentPrefix: params.CommentPrefix,
+		Required:      params.Required,
+	}
+
+	p.On(KeyEvent, func(args ...any) {
+		p.handleKeyPress(args[0].(*Key))
+	})
+
+	return &p
+}
+
+func (p *EditorPrompt) handleKeyPress(key *Key) {
+	if key.Name != EnterKey {
+		return
+	}
+
+	value, err := p.edit()
+	p.editErr = err
+	if err == nil {
+		p.Value = value
+	}
+}
+
+// edit writes the value to a temporary file, waits for the editor to exit and reads the file back without comment lines.
+func (p *EditorPrompt) edit() (string, error) {
+	file, err := os.CreateTemp("", "clack-*"+p.Extension)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.WriteString(p.Value)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if strings.TrimSpace(p.Editor) == "" {
+		return "", errors.New("No editor found. Please set $VISUAL or $EDITOR.")
+	}
+	// The editor takes over the prompt's terminal, so it cannot run on other streams
+	input, isInputFile := p.input.(*os.File)
+	output, isOutputFile := p.output.(*os.File)
+	if !isInputFile || !isOutputFile {
+		return "", errors.New("Could not run an editor, as the input or output is not a terminal.")
+	}
+	cmd := editorCommand(p.Editor, file.Name())
+	cmd.Stdin = input
+	cmd.Stdout = output
+	cmd.Stderr = os.Stderr
+
+	if err := p.Suspend(cmd.Run); err != nil {
+		return "", fmt.Errorf("Could not run %s: %v", p.Editor, err)
+	}
+
+	content, err := os.ReadFile(file.Name())
+	if err != nil {
+		return "", err
+	}
+	return p.stripComments(string(content)), nil
+}
+
+// editorCommand runs the editor on the file through the shell, like git does,
+// so the editor can be quoted or have arguments, unless it is a plain command.
+// On Windows, the editor is split on spaces instead.
+func editorCommand(editor, file string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		args := strings.Fields(editor)
+		return exec.Command(args[0], append(args[1:], file)...)
+	}
+	if !strings.ContainsAny(editor, "|&;<>()$`\\\"' \t\n*?[#~=%") {
+		return exec.Command(editor, file)
+	}
+	return exec.Command("sh", "-c", editor+` "$@"`, editor, file)
+}
+
+// stripComments removes the comment lines, and the leading and trailing blank lines.
+func (p *EditorPrompt) stripComments(content string) string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), p.CommentPrefix) {
+			lines = append(lines, line)
+		}
+	}
+	return strings.TrimLeft(strings.TrimRight(strings.Join(lines, "\n"), " \t\n"), "\n")
+}
+
+// defaultEditor returns the editor set in $VISUAL or $EDITOR, falling back to the platform's default editor.
+func defaultEditor() string {
+	if editor := os.Getenv("VISUAL"); editor != "" {
+		return editor
+	}
+	if editor := os.Getenv("EDITOR"); editor != "" {
+		return editor
+	}
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "vi"
+}
@@ -0,0 +1,123 @@
+package core_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/Mist3rBru/go-clack/core"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeEditor writes a fake editor script that appends a comment and a line to the edited file,
+// failing unless the file has the expected extension.
+func writeEditor(t *testing.T) string {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake editor is a shell script")
+	}
+
+	path := filepath.Join(t.TempDir(), "editor")
+	script := "#!/bin/sh\n" +
+		"case \"$1\" in *.md) ;; *) exit 1 ;; esac\n" +
+		"printf '\\n# comment\\nworld\\n\\n' >> \"$1\"\n"
+	assert.NoError(t, os.WriteFile(path, []byte(script), 0o755))
+	return path
+}
+
+func newEditorPrompt(t *testing.T, params core.EditorPromptParams) *core.EditorPrompt {
+	output, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	assert.NoError(t, err)
+	t.Cleanup(func() { output.Close() })
+
+	params.Output = output
+	params.Render = func(p *core.EditorPrompt) string { return "" }
+	return core.NewEditorPrompt(params)
+}
+
+func TestEditorPromptDefaultEditor(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "nano")
+	assert.Equal(t, "nano", newEditorPrompt(t, core.EditorPromptParams{}).Editor)
+
+	t.Setenv("VISUAL", "code --wait")
+	assert.Equal(t, "code --wait", newEditorPrompt(t, core.EditorPromptParams{}).Editor)
+}
+
+func TestEditorPromptEdit(t *testing.T) {
+	p := newEditorPrompt(t, core.EditorPromptParams{
+		InitialValue: "hello",
+		Editor:       writeEditor(t),
+		Extension:    "md",
+	})
+
+	p.PressKey(&core.Key{Name: core.EnterKey})
+
+	assert.Equal(t, core.SubmitState, p.State)
+	assert.Equal(t, "hello\nworld", p.Value)
+}
+
+func TestEditorPromptQuotedEditor(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "my editor")
+	assert.NoError(t, os.Mkdir(dir, 0o755))
+	path := filepath.Join(dir, "editor")
+	assert.NoError(t, os.Rename(writeEditor(t), path))
+
+	p := newEditorPrompt(t, core.EditorPromptParams{
+		InitialValue: "hello",
+		Editor:       "'" + path + "'",
+		Extension:    "md",
+	})
+
+	p.PressKey(&core.Key{Name: core.EnterKey})
+
+	assert.Equal(t, core.SubmitState, p.State)
+	assert.Equal(t, "hello\nworld", p.Value)
+}
+
+func TestEditorPromptValidate(t *testing.T) {
+	p := newEditorPrompt(t, core.EditorPromptParams{
+		Editor:    writeEditor(t),
+		Extension: ".md",
+		Validate: func(value string) error {
+			assert.Equal(t, "world", value)
+			return nil
+		},
+	})
+
+	p.PressKey(&core.Key{Name: core.EnterKey})
+
+	assert.Equal(t, core.SubmitState, p.State)
+}
+
+func TestEditorPromptEditorError(t *testing.T) {
+	p := newEditorPrompt(t, core.EditorPromptParams{
+		InitialValue: "hello",
+		Editor:       writeEditor(t),
+		Extension:    ".txt",
+	})
+
+	p.PressKey(&core.Key{Name: core.EnterKey})
+
+	assert.Equal(t, core.ErrorState, p.State)
+	assert.Equal(t, "hello", p.Value)
+	assert.Contains(t, p.Error, "Could not run")
+}
+
+func TestEditorPromptNonTerminalOutput(t *testing.T) {
+	p := core.NewEditorPrompt(core.EditorPromptParams{
+		InitialValue: "hello",
+		Output:       &bytes.Buffer{},
+		Editor:       writeEditor(t),
+		Extension:    ".md",
+		Render:       func(p *core.EditorPrompt) string { return "" },
+	})
+
+	p.PressKey(&core.Key{Name: core.EnterKey})
+
+	assert.Equal(t, core.ErrorState, p.State)
+	assert.Equal(t, "hello", p.Value)
+	assert.Contains(t, p.Error, "not a terminal")
+}
@@ -7,7 +7,10 @@ import (
 )
 
 var (
-	ErrCancelPrompt error = errors.New("prompt canceled")
+	ErrCancelPrompt   error = errors.New("prompt canceled")
+	ErrNotTerminal    error = errors.New("output is not a terminal")
+	ErrInputExhausted error = errors.New("input exhausted")
+	ErrInvalidAnswer  error = errors.New("invalid answer")
 )
 
 type FileSystem interface {
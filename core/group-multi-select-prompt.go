@@ -1,7 +1,10 @@
 package core
 
 import (
-	"os"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
 
 	"github.com/Mist3rBru/go-clack/core/utils"
 	"github.com/Mist3rBru/go-clack/core/validator"
@@ -11,20 +14,45 @@ type GroupMultiSelectOption[TValue comparable] struct {
 	MultiSelectOption[TValue]
 	IsGroup bool
 	Options []*GroupMultiSelectOption[TValue]
+	// Parent is the group of the option, or nil at the top level
+	Parent *GroupMultiSelectOption[TValue]
+	// Depth is the number of groups the option is nested in
+	Depth int
+	// IsOpen is whether the options of the group are listed
+	IsOpen bool
+}
+
+// MultiSelectGroup is a named group of options, listed in order by the GroupMultiSelectPrompt.
+type MultiSelectGroup[TValue comparable] struct {
+	Label   string
+	Options []MultiSelectOption[TValue]
+	// Groups are nested in the group, listed after its options
+	Groups []MultiSelectGroup[TValue]
 }
 
 type GroupMultiSelectPrompt[TValue comparable] struct {
 	Prompt[[]TValue]
-	Options        []*GroupMultiSelectOption[TValue]
+	initialOptions []*GroupMultiSelectOption[TValue]
+	// Options are the listed options, leaving out the options of closed groups and the ones filtered out
+	Options []*GroupMultiSelectOption[TValue]
+	Search  string
+	Filter  bool
+	// RegexFilter matches the search as a regular expression, instead of fuzzily
+	RegexFilter    bool
 	DisabledGroups bool
 	Required       bool
 }
 
 type GroupMultiSelectPromptParams[TValue comparable] struct {
-	Input          *os.File
-	Output         *os.File
+	Input  io.Reader
+	Output io.Writer
+	// Groups are listed in order, before the Options
+	Groups []MultiSelectGroup[TValue]
+	// Options are grouped by name, and listed in the groups' alphabetical order
 	Options        map[string][]MultiSelectOption[TValue]
 	InitialValue   []TValue
+	Filter         bool
+	RegexFilter    bool
 	DisabledGroups bool
 	Required       bool
 	Validate       func(value []TValue) error
@@ -34,9 +62,10 @@ type GroupMultiSelectPromptParams[TValue comparable] struct {
 func NewGroupMultiSelectPrompt[TValue comparable](params GroupMultiSelectPromptParams[TValue]) *GroupMultiSelectPrompt[TValue] {
 	v := validator.NewValidator("GroupMultiSelectPrompt")
 	v.ValidateRender(params.Render)
-	v.ValidateOptions(len(params.Options))
+	v.ValidateOptions(len(params.Groups) + len(params.Options))
 
-	options := mapGroupMultiSelectOptions(params.Options)
+	groups := append(params.Groups[:len(params.Groups):len(params.Groups)], sortMultiSelectGroups(params.Options)...)
+	options := mapGroupMultiSelectOptions(groups, nil)
 
 	var p GroupMultiSelectPrompt[TValue]
 	p = GroupMultiSelectPrompt[TValue]{
@@ -45,16 +74,18 @@ func NewGroupMultiSelectPrompt[TValue comparable](params GroupMultiSelectPromptP
 			Output:       params.Output,
 			InitialValue: mapGroupMultiSelectInitialValue(params.InitialValue, options),
 			Validate:     WrapValidate(params.Validate, &p.Required, "Please select at least one option. Press `space` to select"),
+			ParseAnswer:  p.handleAnswer,
+			ParseAnswers: p.handleAnswers,
 			Render:       WrapRender[[]TValue](&p, params.Render),
 		}),
+		initialOptions: options,
 		Options:        options,
+		Filter:         params.Filter,
+		RegexFilter:    params.RegexFilter,
 		DisabledGroups: params.DisabledGroups,
 		Required:       params.Required,
 	}
-
-	if p.DisabledGroups {
-		p.CursorIndex = 1
-	}
+	p.CursorIndex = nextSelectableIndex(-1, 1, len(p.Options), p.isSelectable)
 
 	p.On(KeyEvent, func(args ...any) {
 		p.handleKeyPress(args[0].(*Key))
@@ -65,56 +96,226 @@ func NewGroupMultiSelectPrompt[TValue comparable](params GroupMultiSelectPromptP
 
 func (p *GroupMultiSelectPrompt[TValue]) handleKeyPress(key *Key) {
 	switch key.Name {
-	case UpKey, LeftKey:
-		p.CursorIndex = utils.MinMaxIndex(p.CursorIndex-1, len(p.Options))
-		if p.DisabledGroups && p.Options[p.CursorIndex].IsGroup {
-			p.CursorIndex = utils.MinMaxIndex(p.CursorIndex-1, len(p.Options))
-		}
-	case DownKey, RightKey:
-		p.CursorIndex = utils.MinMaxIndex(p.CursorIndex+1, len(p.Options))
-		if p.DisabledGroups && p.Options[p.CursorIndex].IsGroup {
-			p.CursorIndex = utils.MinMaxIndex(p.CursorIndex+1, len(p.Options))
-		}
+	case UpKey:
+		p.CursorIndex = nextSelectableIndex(p.CursorIndex, -1, len(p.Options), p.isSelectable)
+	case DownKey:
+		p.CursorIndex = nextSelectableIndex(p.CursorIndex, 1, len(p.Options), p.isSelectable)
 	case HomeKey:
-		p.CursorIndex = 0
+		p.CursorIndex = nextSelectableIndex(-1, 1, len(p.Options), p.isSelectable)
 	case EndKey:
-		p.CursorIndex = len(p.Options) - 1
+		p.CursorIndex = nextSelectableIndex(len(p.Options), -1, len(p.Options), p.isSelectable)
+	case LeftKey:
+		p.closeGroup()
+	case RightKey:
+		p.openGroup()
 	case SpaceKey:
 		p.toggleOption()
+	case EnterKey, CancelKey:
+	default:
+		if p.Filter {
+			p.Search, _ = p.TrackKeyValue(key, p.Search, utils.GraphemeCount(p.Search))
+			p.listOptions(p.currentOption())
+		}
+	}
+}
+
+// isSelectable reports whether the cursor can be moved to the option,
+// which leaves out the open groups if the groups are disabled, as their options are listed already.
+func (p *GroupMultiSelectPrompt[TValue]) isSelectable(index int) bool {
+	option := p.Options[index]
+	return !p.DisabledGroups || !option.IsGroup || !p.isOpen(option)
+}
+
+// isOpen reports whether the options of the group are listed, as groups are open while searching.
+func (p *GroupMultiSelectPrompt[TValue]) isOpen(group *GroupMultiSelectOption[TValue]) bool {
+	return group.IsOpen || p.Search != ""
+}
+
+func (p *GroupMultiSelectPrompt[TValue]) currentOption() *GroupMultiSelectOption[TValue] {
+	if p.CursorIndex >= 0 && p.CursorIndex < len(p.Options) {
+		return p.Options[p.CursorIndex]
+	}
+	return nil
+}
+
+// closeGroup closes the group under the cursor, or the group of the option under the cursor, moving the cursor to it.
+func (p *GroupMultiSelectPrompt[TValue]) closeGroup() {
+	p.Search = ""
+	option := p.currentOption()
+	if option == nil {
+		p.listOptions(nil)
+		return
+	}
+
+	if option.IsGroup && option.IsOpen {
+		option.IsOpen = false
+	} else if option.Parent != nil {
+		option = option.Parent
+		option.IsOpen = false
+	}
+	p.listOptions(option)
+}
+
+// openGroup opens the group under the cursor, or moves the cursor to its first option if it is open already.
+func (p *GroupMultiSelectPrompt[TValue]) openGroup() {
+	p.Search = ""
+	option := p.currentOption()
+	if option == nil {
+		p.listOptions(nil)
+		return
+	}
+
+	if option.IsGroup && option.IsOpen && len(option.Options) > 0 {
+		option = option.Options[0]
+	} else if option.IsGroup {
+		option.IsOpen = true
+	}
+	p.listOptions(option)
+}
+
+// listOptions lists the options of the open groups, or the ones matching the search,
+// keeping the cursor on the current option if it is still listed, or else on the first match.
+func (p *GroupMultiSelectPrompt[TValue]) listOptions(current *GroupMultiSelectOption[TValue]) {
+	for _, option := range p.initialOptions {
+		option.Matches = nil
+	}
+
+	if p.Search == "" {
+		p.Options = p.openOptions(p.topOptions())
+	} else {
+		p.Options = p.filterOptions(p.topOptions(), false)
+		// The groups listed for their matching options are not matches themselves
+		if current != nil && current.Matches == nil {
+			current = nil
+			for _, option := range p.Options {
+				if option.Matches != nil {
+					current = option
+					break
+				}
+			}
+		}
+	}
+
+	p.CursorIndex = nextSelectableIndex(-1, 1, len(p.Options), p.isSelectable)
+	for i, option := range p.Options {
+		if option == current && p.isSelectable(i) {
+			p.CursorIndex = i
+			break
+		}
+	}
+}
+
+func (p *GroupMultiSelectPrompt[TValue]) topOptions() []*GroupMultiSelectOption[TValue] {
+	var options []*GroupMultiSelectOption[TValue]
+	for _, option := range p.initialOptions {
+		if option.Parent == nil {
+			options = append(options, option)
+		}
+	}
+	return options
+}
+
+func (p *GroupMultiSelectPrompt[TValue]) openOptions(options []*GroupMultiSelectOption[TValue]) []*GroupMultiSelectOption[TValue] {
+	var openOptions []*GroupMultiSelectOption[TValue]
+	for _, option := range options {
+		openOptions = append(openOptions, option)
+		if option.IsGroup && option.IsOpen {
+			openOptions = append(openOptions, p.openOptions(option.Options)...)
+		}
+	}
+	return openOptions
+}
+
+// filterOptions lists the options matching the search, ranked among their siblings, followed by the groups
+// containing matching options. The options of matching groups are all listed, in order.
+func (p *GroupMultiSelectPrompt[TValue]) filterOptions(options []*GroupMultiSelectOption[TValue], listAll bool) []*GroupMultiSelectOption[TValue] {
+	matchedOptions := filterSearch(options, p.Search, p.RegexFilter,
+		func(option *GroupMultiSelectOption[TValue]) string { return option.Label },
+		func(option *GroupMultiSelectOption[TValue], matches []int) { option.Matches = matches },
+	)
+	isMatched := make(map[*GroupMultiSelectOption[TValue]]bool, len(matchedOptions))
+	for _, option := range matchedOptions {
+		isMatched[option] = true
+	}
+
+	sortedOptions := options
+	if !listAll {
+		sortedOptions = matchedOptions
+		for _, option := range options {
+			if option.IsGroup && !isMatched[option] {
+				sortedOptions = append(sortedOptions, option)
+			}
+		}
+	}
+
+	var filteredOptions []*GroupMultiSelectOption[TValue]
+	for _, option := range sortedOptions {
+		var groupOptions []*GroupMultiSelectOption[TValue]
+		if option.IsGroup {
+			groupOptions = p.filterOptions(option.Options, listAll || isMatched[option])
+		}
+		if listAll || isMatched[option] || len(groupOptions) > 0 {
+			filteredOptions = append(filteredOptions, option)
+			filteredOptions = append(filteredOptions, groupOptions...)
+		}
 	}
+	return filteredOptions
+}
+
+// groupOptions returns the options nested in the group, at any depth, leaving out the groups.
+func (p *GroupMultiSelectPrompt[TValue]) groupOptions(group *GroupMultiSelectOption[TValue]) []*GroupMultiSelectOption[TValue] {
+	var options []*GroupMultiSelectOption[TValue]
+	for _, option := range group.Options {
+		if option.IsGroup {
+			options = append(options, p.groupOptions(option)...)
+			continue
+		}
+		options = append(options, option)
+	}
+	return options
 }
 
 func (p *GroupMultiSelectPrompt[TValue]) IsGroupSelected(group *GroupMultiSelectOption[TValue]) bool {
 	if p.DisabledGroups {
 		return false
 	}
-	for _, option := range group.Options {
+	for _, option := range p.groupOptions(group) {
 		if !option.IsSelected {
 			return false
 		}
-
 	}
 	return true
 }
 
+// IsGroupIndeterminate reports whether some, but not all, of the group's options are selected.
+func (p *GroupMultiSelectPrompt[TValue]) IsGroupIndeterminate(group *GroupMultiSelectOption[TValue]) bool {
+	if p.DisabledGroups || p.IsGroupSelected(group) {
+		return false
+	}
+	for _, option := range p.groupOptions(group) {
+		if option.IsSelected {
+			return true
+		}
+	}
+	return false
+}
+
 func (p *GroupMultiSelectPrompt[TValue]) toggleOption() {
-	option := p.Options[p.CursorIndex]
+	option := p.currentOption()
+	if option == nil || (option.IsGroup && p.DisabledGroups) {
+		return
+	}
 
 	if option.IsGroup && p.IsGroupSelected(option) {
-		for _, option := range option.Options {
+		for _, option := range p.groupOptions(option) {
 			option.IsSelected = false
 		}
-		p.Value = []TValue{}
-		for _, option := range p.Options {
-			if option.IsSelected {
-				p.Value = append(p.Value, option.Value)
-			}
-		}
+		p.mapValue()
 		return
 	}
 
 	if option.IsGroup {
-		for _, option := range option.Options {
+		for _, option := range p.groupOptions(option) {
 			if !option.IsSelected {
 				option.IsSelected = true
 				p.Value = append(p.Value, option.Value)
@@ -125,12 +326,7 @@ func (p *GroupMultiSelectPrompt[TValue]) toggleOption() {
 
 	if option.IsSelected {
 		option.IsSelected = false
-		p.Value = []TValue{}
-		for _, _option := range p.Options {
-			if _option.IsSelected {
-				p.Value = append(p.Value, _option.Value)
-			}
-		}
+		p.mapValue()
 		return
 	}
 
@@ -138,32 +334,120 @@ func (p *GroupMultiSelectPrompt[TValue]) toggleOption() {
 	p.Value = append(p.Value, option.Value)
 }
 
-func mapGroupMultiSelectOptions[TValue comparable](groups map[string][]MultiSelectOption[TValue]) []*GroupMultiSelectOption[TValue] {
+// mapValue sets the value to the selected options, in order.
+func (p *GroupMultiSelectPrompt[TValue]) mapValue() {
+	p.Value = []TValue{}
+	for _, option := range p.initialOptions {
+		if !option.IsGroup && option.IsSelected {
+			p.Value = append(p.Value, option.Value)
+		}
+	}
+}
+
+// SelectedOptions returns the selected options in order, including the ones in closed groups or filtered out.
+func (p *GroupMultiSelectPrompt[TValue]) SelectedOptions() []*GroupMultiSelectOption[TValue] {
 	var options []*GroupMultiSelectOption[TValue]
+	for _, option := range p.initialOptions {
+		if !option.IsGroup && option.IsSelected {
+			options = append(options, option)
+		}
+	}
+	return options
+}
+
+func (p *GroupMultiSelectPrompt[TValue]) handleAnswer(answer string) error {
+	if answer == "" {
+		return nil
+	}
+	return p.handleAnswers(splitAnswer(answer))
+}
+
+func (p *GroupMultiSelectPrompt[TValue]) handleAnswers(answers []string) error {
+	selected := make(map[*GroupMultiSelectOption[TValue]]bool)
+	for _, item := range answers {
+		found := false
+		for _, option := range p.initialOptions {
+			if option.IsGroup && !p.DisabledGroups && strings.EqualFold(item, option.Label) {
+				for _, groupOption := range p.groupOptions(option) {
+					selected[groupOption] = true
+				}
+				found = true
+				break
+			}
+			if !option.IsGroup && matchAnswer(item, option.Label, option.Value) {
+				selected[option] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("Invalid option: %s", item)
+		}
+	}
 
-	for groupName, groupOptions := range groups {
+	for _, option := range p.initialOptions {
+		option.IsSelected = !option.IsGroup && selected[option]
+	}
+	p.mapValue()
+	return nil
+}
+
+// sortMultiSelectGroups lists the groups in alphabetical order, as maps are ranged over in random order.
+func sortMultiSelectGroups[TValue comparable](groups map[string][]MultiSelectOption[TValue]) []MultiSelectGroup[TValue] {
+	sortedGroups := make([]MultiSelectGroup[TValue], 0, len(groups))
+	for label, options := range groups {
+		sortedGroups = append(sortedGroups, MultiSelectGroup[TValue]{Label: label, Options: options})
+	}
+	sort.Slice(sortedGroups, func(i, j int) bool {
+		return sortedGroups[i].Label < sortedGroups[j].Label
+	})
+	return sortedGroups
+}
+
+// mapGroupMultiSelectOptions lists the groups, each followed by its options and nested groups, all of them open.
+func mapGroupMultiSelectOptions[TValue comparable](groups []MultiSelectGroup[TValue], parent *GroupMultiSelectOption[TValue]) []*GroupMultiSelectOption[TValue] {
+	var options []*GroupMultiSelectOption[TValue]
+
+	depth := 0
+	if parent != nil {
+		depth = parent.Depth + 1
+	}
+
+	for _, groupParams := range groups {
 		group := &GroupMultiSelectOption[TValue]{
 			MultiSelectOption: MultiSelectOption[TValue]{
-				Label: groupName,
+				Label: groupParams.Label,
 			},
 			IsGroup: true,
-			Options: make([]*GroupMultiSelectOption[TValue], len(groupOptions)),
+			Parent:  parent,
+			Depth:   depth,
+			IsOpen:  true,
 		}
 		options = append(options, group)
-		for i, groupOption := range groupOptions {
+		for _, groupOption := range groupParams.Options {
 			option := &GroupMultiSelectOption[TValue]{
 				MultiSelectOption: MultiSelectOption[TValue]{
 					Label:      groupOption.Label,
 					Value:      groupOption.Value,
 					IsSelected: groupOption.IsSelected,
 				},
+				Parent: group,
+				Depth:  depth + 1,
 			}
 			if value, ok := any(option.Value).(string); ok && value == "" {
 				option.Value = any(option.Label).(TValue)
 			}
-			group.Options[i] = option
+			group.Options = append(group.Options, option)
 			options = append(options, option)
 		}
+
+		nestedOptions := mapGroupMultiSelectOptions(groupParams.Groups, group)
+		for _, option := range nestedOptions {
+			if option.Parent == group {
+				group.Options = append(group.Options, option)
+			}
+		}
+		options = append(options, nestedOptions...)
 	}
 
 	return options
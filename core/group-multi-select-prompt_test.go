@@ -33,11 +33,11 @@ func TestChangeGroupMultiSelectCursor(t *testing.T) {
 	assert.Equal(t, 0, p.CursorIndex)
 	p.PressKey(&core.Key{Name: core.DownKey})
 	assert.Equal(t, 1, p.CursorIndex)
-	p.PressKey(&core.Key{Name: core.RightKey})
+	p.PressKey(&core.Key{Name: core.DownKey})
 	assert.Equal(t, 2, p.CursorIndex)
 	p.PressKey(&core.Key{Name: core.UpKey})
 	assert.Equal(t, 1, p.CursorIndex)
-	p.PressKey(&core.Key{Name: core.LeftKey})
+	p.PressKey(&core.Key{Name: core.UpKey})
 	assert.Equal(t, 0, p.CursorIndex)
 
 	p.PressKey(&core.Key{Name: core.EndKey})
@@ -223,3 +223,171 @@ func TestGroupMultiSelectDisabledGroups(t *testing.T) {
 	p.PressKey(&core.Key{Name: core.UpKey})
 	assert.Equal(t, 5, p.CursorIndex)
 }
+
+func groupMultiSelectLabels(options []*core.GroupMultiSelectOption[string]) []string {
+	labels := make([]string, len(options))
+	for i, option := range options {
+		labels[i] = option.Label
+	}
+	return labels
+}
+
+func TestGroupMultiSelectSortedOptions(t *testing.T) {
+	p := core.NewGroupMultiSelectPrompt(core.GroupMultiSelectPromptParams[string]{
+		Options: map[string][]core.MultiSelectOption[string]{
+			"c": {{Label: "c1"}},
+			"a": {{Label: "a1"}},
+			"b": {{Label: "b1"}},
+		},
+		Render: func(p *core.GroupMultiSelectPrompt[string]) string { return "" },
+	})
+
+	assert.Equal(t, []string{"a", "a1", "b", "b1", "c", "c1"}, groupMultiSelectLabels(p.Options))
+}
+
+func TestGroupMultiSelectOrderedGroups(t *testing.T) {
+	p := core.NewGroupMultiSelectPrompt(core.GroupMultiSelectPromptParams[string]{
+		Groups: []core.MultiSelectGroup[string]{
+			{Label: "z", Options: []core.MultiSelectOption[string]{{Label: "z1"}, {Label: "z2"}}},
+			{Label: "x", Options: []core.MultiSelectOption[string]{{Label: "x1"}}},
+		},
+		Options: map[string][]core.MultiSelectOption[string]{
+			"y": {{Label: "y1"}},
+		},
+		Render: func(p *core.GroupMultiSelectPrompt[string]) string { return "" },
+	})
+
+	assert.Equal(t, []string{"z", "z1", "z2", "x", "x1", "y", "y1"}, groupMultiSelectLabels(p.Options))
+	assert.Equal(t, 2, len(p.Options[0].Options))
+}
+
+func newNestedGroupMultiSelectPrompt() *core.GroupMultiSelectPrompt[string] {
+	return core.NewGroupMultiSelectPrompt(core.GroupMultiSelectPromptParams[string]{
+		Groups: []core.MultiSelectGroup[string]{
+			{
+				Label: "web",
+				Groups: []core.MultiSelectGroup[string]{
+					{Label: "auth", Options: []core.MultiSelectOption[string]{{Label: "login"}, {Label: "signup"}}},
+					{Label: "billing", Options: []core.MultiSelectOption[string]{{Label: "invoices"}}},
+				},
+			},
+			{
+				Label:   "infra",
+				Options: []core.MultiSelectOption[string]{{Label: "terraform"}},
+			},
+		},
+		Filter: true,
+		Render: func(p *core.GroupMultiSelectPrompt[string]) string { return "" },
+	})
+}
+
+func TestGroupMultiSelectNestedGroups(t *testing.T) {
+	p := newNestedGroupMultiSelectPrompt()
+
+	assert.Equal(t, []string{"web", "auth", "login", "signup", "billing", "invoices", "infra", "terraform"}, groupMultiSelectLabels(p.Options))
+	assert.Equal(t, []int{0, 1, 2, 2, 1, 2, 0, 1}, []int{
+		p.Options[0].Depth, p.Options[1].Depth, p.Options[2].Depth, p.Options[3].Depth,
+		p.Options[4].Depth, p.Options[5].Depth, p.Options[6].Depth, p.Options[7].Depth,
+	})
+	assert.Equal(t, p.Options[0], p.Options[1].Parent)
+
+	p.PressKey(&core.Key{Name: core.SpaceKey})
+	assert.Equal(t, []string{"login", "signup", "invoices"}, p.Value)
+	assert.True(t, p.IsGroupSelected(p.Options[1]))
+}
+
+func TestGroupMultiSelectIndeterminateGroup(t *testing.T) {
+	p := newNestedGroupMultiSelectPrompt()
+
+	p.CursorIndex = 2
+	p.PressKey(&core.Key{Name: core.SpaceKey})
+	assert.Equal(t, []string{"login"}, p.Value)
+	assert.True(t, p.IsGroupIndeterminate(p.Options[0]))
+	assert.True(t, p.IsGroupIndeterminate(p.Options[1]))
+	assert.False(t, p.IsGroupIndeterminate(p.Options[4]))
+
+	p.PressKey(&core.Key{Name: core.DownKey})
+	p.PressKey(&core.Key{Name: core.SpaceKey})
+	assert.False(t, p.IsGroupIndeterminate(p.Options[1]))
+	assert.True(t, p.IsGroupSelected(p.Options[1]))
+	assert.True(t, p.IsGroupIndeterminate(p.Options[0]))
+}
+
+func TestGroupMultiSelectCollapseGroups(t *testing.T) {
+	p := newNestedGroupMultiSelectPrompt()
+
+	p.CursorIndex = 3
+	p.PressKey(&core.Key{Name: core.LeftKey})
+	assert.Equal(t, []string{"web", "auth", "billing", "invoices", "infra", "terraform"}, groupMultiSelectLabels(p.Options))
+	assert.Equal(t, 1, p.CursorIndex)
+
+	p.PressKey(&core.Key{Name: core.LeftKey})
+	assert.Equal(t, []string{"web", "infra", "terraform"}, groupMultiSelectLabels(p.Options))
+	assert.Equal(t, 0, p.CursorIndex)
+
+	p.PressKey(&core.Key{Name: core.RightKey})
+	assert.Equal(t, []string{"web", "auth", "billing", "invoices", "infra", "terraform"}, groupMultiSelectLabels(p.Options))
+	assert.Equal(t, 0, p.CursorIndex)
+
+	p.PressKey(&core.Key{Name: core.RightKey})
+	assert.Equal(t, 1, p.CursorIndex)
+	p.PressKey(&core.Key{Name: core.RightKey})
+	assert.Equal(t, []string{"web", "auth", "login", "signup", "billing", "invoices", "infra", "terraform"}, groupMultiSelectLabels(p.Options))
+}
+
+func TestGroupMultiSelectCollapseDisabledGroups(t *testing.T) {
+	p := newNestedGroupMultiSelectPrompt()
+	p.DisabledGroups = true
+
+	p.CursorIndex = 2
+	p.PressKey(&core.Key{Name: core.LeftKey})
+	assert.Equal(t, 1, p.CursorIndex)
+
+	p.PressKey(&core.Key{Name: core.SpaceKey})
+	assert.Empty(t, p.Value)
+
+	p.PressKey(&core.Key{Name: core.DownKey})
+	assert.Equal(t, "invoices", p.Options[p.CursorIndex].Label)
+	p.PressKey(&core.Key{Name: core.UpKey})
+	assert.Equal(t, "auth", p.Options[p.CursorIndex].Label)
+}
+
+func TestGroupMultiSelectFilter(t *testing.T) {
+	p := newNestedGroupMultiSelectPrompt()
+
+	p.PressKey(&core.Key{Char: "i"})
+	p.PressKey(&core.Key{Char: "n"})
+	p.PressKey(&core.Key{Char: "v"})
+	assert.Equal(t, []string{"web", "billing", "invoices"}, groupMultiSelectLabels(p.Options))
+	assert.Equal(t, []int{0, 1, 2}, p.Options[2].Matches)
+	assert.Equal(t, 2, p.CursorIndex)
+
+	p.PressKey(&core.Key{Name: core.SpaceKey})
+	assert.Equal(t, []string{"invoices"}, p.Value)
+}
+
+func TestGroupMultiSelectFilterGroup(t *testing.T) {
+	p := newNestedGroupMultiSelectPrompt()
+
+	p.PressKey(&core.Key{Char: "a"})
+	p.PressKey(&core.Key{Char: "u"})
+	p.PressKey(&core.Key{Char: "t"})
+	p.PressKey(&core.Key{Char: "h"})
+	assert.Equal(t, []string{"web", "auth", "login", "signup"}, groupMultiSelectLabels(p.Options))
+
+	p.PressKey(&core.Key{Name: core.LeftKey})
+	assert.Equal(t, "", p.Search)
+	assert.Nil(t, p.Options[1].Matches)
+}
+
+func TestGroupMultiSelectSelectedOptions(t *testing.T) {
+	p := newGroupMultiSelectPrompt()
+
+	p.PressKey(&core.Key{Name: core.DownKey})
+	p.PressKey(&core.Key{Name: core.SpaceKey})
+	p.PressKey(&core.Key{Name: core.UpKey})
+	p.PressKey(&core.Key{Name: core.LeftKey})
+
+	assert.Equal(t, 1, len(p.SelectedOptions()))
+	assert.Equal(t, "a", p.SelectedOptions()[0].Value)
+}
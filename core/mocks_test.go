@@ -1,6 +1,10 @@
 package core_test
 
-import "os"
+import (
+	"bytes"
+	"os"
+	"sync"
+)
 
 type MockDirEntry struct {
 	name  string
@@ -26,13 +30,19 @@ func (e MockDirEntry) Info() (os.FileInfo, error) {
 	return nil, nil
 }
 
-type MockFileSystem struct{}
+// MockFileSystem lists the same entries in every directory, a dir and a file unless Entries is set.
+type MockFileSystem struct {
+	Entries []os.DirEntry
+}
 
 func (fs MockFileSystem) Getwd() (string, error) {
 	return "/clack", nil
 }
 
 func (fs MockFileSystem) ReadDir(name string) ([]os.DirEntry, error) {
+	if fs.Entries != nil {
+		return fs.Entries, nil
+	}
 	return []os.DirEntry{
 		MockDirEntry{name: "dir", isDir: true},
 		MockDirEntry{name: "file", isDir: false},
@@ -42,3 +52,66 @@ func (fs MockFileSystem) ReadDir(name string) ([]os.DirEntry, error) {
 func (fs MockFileSystem) UserHomeDir() (string, error) {
 	return "/home/clack", nil
 }
+
+type MockTerminal struct {
+	bytes.Buffer
+	mu     sync.Mutex
+	width  int
+	height int
+	resize chan struct{}
+}
+
+func (t *MockTerminal) Size() (int, int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.width, t.height, nil
+}
+
+func (t *MockTerminal) ResizeEvents() <-chan struct{} {
+	return t.resize
+}
+
+func (t *MockTerminal) Resize(width, height int) {
+	t.mu.Lock()
+	t.width, t.height = width, height
+	t.mu.Unlock()
+	t.resize <- struct{}{}
+}
+
+// MockOutput is a buffer that can be read while a prompt writes to it.
+type MockOutput struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (o *MockOutput) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.buf.Write(p)
+}
+
+func (o *MockOutput) String() string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.buf.String()
+}
+
+// NewPipeInput returns a non-terminal file that reads the given data.
+func NewPipeInput(data string) *os.File {
+	r, w, err := os.Pipe()
+	if err != nil {
+		panic(err)
+	}
+	w.WriteString(data)
+	w.Close()
+	return r
+}
+
+// filterEntries are the directory entries of the path filter tests.
+var filterEntries = []os.DirEntry{
+	MockDirEntry{name: "dir", isDir: true},
+	MockDirEntry{name: "bar.go"},
+	MockDirEntry{name: "config.go"},
+	MockDirEntry{name: "file"},
+	MockDirEntry{name: "foo.go"},
+}
@@ -1,7 +1,7 @@
 package core
 
 import (
-	"os"
+	"io"
 	"path"
 	"sort"
 
@@ -16,19 +16,22 @@ type MultiSelectPathPrompt struct {
 	CurrentOption *PathNode
 	OnlyShowDir   bool
 	Filter        bool
-	Search        string
-	Required      bool
-	FileSystem    FileSystem
+	// RegexFilter matches the search as a regular expression, instead of fuzzily
+	RegexFilter bool
+	Search      string
+	Required    bool
+	FileSystem  FileSystem
 }
 
 type MultiSelectPathPromptParams struct {
-	Input        *os.File
-	Output       *os.File
+	Input        io.Reader
+	Output       io.Writer
 	InitialValue []string
 	InitialPath  string
 	OnlyShowDir  bool
 	Required     bool
 	Filter       bool
+	RegexFilter  bool
 	FileSystem   FileSystem
 	Validate     func(value []string) error
 	Render       func(p *MultiSelectPathPrompt) string
@@ -50,10 +53,13 @@ func NewMultiSelectPathPrompt(params MultiSelectPathPromptParams) *MultiSelectPa
 			InitialValue: params.InitialValue,
 			CursorIndex:  1,
 			Validate:     WrapValidate(params.Validate, &p.Required, "Please select at least one option. Press `space` to select"),
+			ParseAnswer:  p.handleAnswer,
+			ParseAnswers: p.handleAnswers,
 			Render:       WrapRender[[]string](&p, params.Render),
 		}),
 		OnlyShowDir: params.OnlyShowDir,
 		Filter:      params.Filter,
+		RegexFilter: params.RegexFilter,
 		Required:    params.Required,
 		FileSystem:  params.FileSystem,
 	}
@@ -63,6 +69,7 @@ func NewMultiSelectPathPrompt(params MultiSelectPathPromptParams) *MultiSelectPa
 	}
 	p.Root = NewPathNode(params.InitialPath, PathNodeOptions{
 		OnlyShowDir: p.OnlyShowDir,
+		RegexFilter: p.RegexFilter,
 		FileSystem:  p.FileSystem,
 	})
 	p.CurrentOption = p.Root.FirstChild()
@@ -108,6 +115,7 @@ func (p *MultiSelectPathPrompt) handleKeyPress(key *Key) {
 		if p.CurrentOption.IsRoot() {
 			p.Root = NewPathNode(path.Dir(p.Root.Path), PathNodeOptions{
 				OnlyShowDir: p.OnlyShowDir,
+				RegexFilter: p.RegexFilter,
 				FileSystem:  p.FileSystem,
 			})
 			p.CurrentOption = p.Root
@@ -157,7 +165,7 @@ func (p *MultiSelectPathPrompt) handleKeyPress(key *Key) {
 		p.Value = append(p.Value, p.CurrentOption.Path)
 	default:
 		if p.Filter {
-			p.Search, _ = p.TrackKeyValue(key, p.Search, len(p.Search))
+			p.Search, _ = p.TrackKeyValue(key, p.Search, utils.GraphemeCount(p.Search))
 			if !p.CurrentOption.IsRoot() {
 				layerOptions := p.CurrentOption.FilteredLayer(p.Search)
 				layerIndex := p.Root.IndexOf(p.CurrentOption, layerOptions)
@@ -173,6 +181,22 @@ func (p *MultiSelectPathPrompt) handleKeyPress(key *Key) {
 	}
 }
 
+func (p *MultiSelectPathPrompt) handleAnswer(answer string) error {
+	if answer == "" {
+		return nil
+	}
+	return p.handleAnswers(splitAnswer(answer))
+}
+
+func (p *MultiSelectPathPrompt) handleAnswers(answers []string) error {
+	p.Value = []string{}
+	for _, item := range answers {
+		p.Value = append(p.Value, p.Root.resolvePath(item))
+	}
+	p.mapSelectedOptions(p.Root)
+	return nil
+}
+
 func (p *MultiSelectPathPrompt) mapSelectedOptions(node *PathNode) {
 	node.TraverseNodes(func(node *PathNode) {
 		for _, path := range p.Value {
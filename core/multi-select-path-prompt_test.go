@@ -162,15 +162,23 @@ func TestMultiSelectPathFilter(t *testing.T) {
 
 func TestMultiSelectPathFilterNavigate(t *testing.T) {
 	p := core.NewMultiSelectPathPrompt(core.MultiSelectPathPromptParams{
-		Filter: true,
-		Render: func(p *core.MultiSelectPathPrompt) string { return "" },
+		Filter:     true,
+		FileSystem: MockFileSystem{Entries: filterEntries},
+		Render:     func(p *core.MultiSelectPathPrompt) string { return "" },
 	})
 
+	// Prefix matches rank first, shorter names first, followed by the fuzzy match
 	p.PressKey(&core.Key{Char: "f"})
-	assert.Equal(t, 3, p.CurrentOption.Index)
+	assert.Equal(t, "file", p.CurrentOption.Name)
+
+	p.PressKey(&core.Key{Name: core.DownKey})
+	assert.Equal(t, "foo.go", p.CurrentOption.Name)
+
+	p.PressKey(&core.Key{Name: core.DownKey})
+	assert.Equal(t, "config.go", p.CurrentOption.Name)
 
 	p.PressKey(&core.Key{Name: core.DownKey})
-	assert.Equal(t, 4, p.CurrentOption.Index)
+	assert.Equal(t, "file", p.CurrentOption.Name)
 }
 
 func TestMultiSelectPathFilterRecover(t *testing.T) {
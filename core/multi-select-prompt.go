@@ -1,8 +1,8 @@
 package core
 
 import (
-	"os"
-	"regexp"
+	"fmt"
+	"io"
 
 	"github.com/Mist3rBru/go-clack/core/utils"
 	"github.com/Mist3rBru/go-clack/core/validator"
@@ -12,6 +12,16 @@ type MultiSelectOption[TValue comparable] struct {
 	Label      string
 	Value      TValue
 	IsSelected bool
+	// Matches are the grapheme indexes of the label matched by the search
+	Matches []int
+	// IsDisabled shows the option without letting it be selected
+	IsDisabled bool
+	// IsSeparator shows the label as a heading between the options
+	IsSeparator bool
+}
+
+func (o *MultiSelectOption[TValue]) isSelectable() bool {
+	return !o.IsDisabled && !o.IsSeparator
 }
 
 type MultiSelectPrompt[TValue comparable] struct {
@@ -20,16 +30,25 @@ type MultiSelectPrompt[TValue comparable] struct {
 	Options        []*MultiSelectOption[TValue]
 	Search         string
 	Filter         bool
-	Required       bool
+	// RegexFilter matches the search as a regular expression, instead of fuzzily
+	RegexFilter bool
+	Required    bool
+	// Min is the least number of selected options to submit, if not zero
+	Min int
+	// Max is the most number of selected options, blocking further selections once reached, if not zero
+	Max int
 }
 
 type MultiSelectPromptParams[TValue comparable] struct {
-	Input        *os.File
-	Output       *os.File
+	Input        io.Reader
+	Output       io.Writer
 	InitialValue []TValue
 	Options      []*MultiSelectOption[TValue]
 	Filter       bool
+	RegexFilter  bool
 	Required     bool
+	Min          int
+	Max          int
 	Validate     func(value []TValue) error
 	Render       func(p *MultiSelectPrompt[TValue]) string
 }
@@ -40,7 +59,7 @@ func NewMultiSelectPrompt[TValue comparable](params MultiSelectPromptParams[TVal
 	v.ValidateOptions(len(params.Options))
 
 	for _, option := range params.Options {
-		if value, ok := any(option.Value).(string); ok && value == "" {
+		if value, ok := any(option.Value).(string); ok && value == "" && !option.IsSeparator {
 			option.Value = any(option.Label).(TValue)
 		}
 	}
@@ -51,14 +70,20 @@ func NewMultiSelectPrompt[TValue comparable](params MultiSelectPromptParams[TVal
 			Input:        params.Input,
 			Output:       params.Output,
 			InitialValue: mapMultiSelectInitialValue(params.InitialValue, params.Options),
-			Validate:     WrapValidate(params.Validate, &p.Required, "Please select at least one option. Press `space` to select"),
+			Validate:     WrapValidate(p.validateCount(params.Validate), &p.Required, "Please select at least one option. Press `space` to select"),
+			ParseAnswer:  p.handleAnswer,
+			ParseAnswers: p.handleAnswers,
 			Render:       WrapRender[[]TValue](&p, params.Render),
 		}),
 		initialOptions: params.Options,
 		Options:        params.Options,
 		Filter:         params.Filter,
+		RegexFilter:    params.RegexFilter,
 		Required:       params.Required,
+		Min:            params.Min,
+		Max:            params.Max,
 	}
+	p.CursorIndex = nextSelectableIndex(-1, 1, len(p.Options), p.isSelectable)
 
 	p.On(KeyEvent, func(args ...any) {
 		p.handleKeyPress(args[0].(*Key))
@@ -70,15 +95,15 @@ func NewMultiSelectPrompt[TValue comparable](params MultiSelectPromptParams[TVal
 func (p *MultiSelectPrompt[TValue]) handleKeyPress(key *Key) {
 	switch key.Name {
 	case UpKey, LeftKey:
-		p.CursorIndex = utils.MinMaxIndex(p.CursorIndex-1, len(p.Options))
+		p.CursorIndex = nextSelectableIndex(p.CursorIndex, -1, len(p.Options), p.isSelectable)
 	case DownKey, RightKey:
-		p.CursorIndex = utils.MinMaxIndex(p.CursorIndex+1, len(p.Options))
+		p.CursorIndex = nextSelectableIndex(p.CursorIndex, 1, len(p.Options), p.isSelectable)
 	case HomeKey:
-		p.CursorIndex = 0
+		p.CursorIndex = nextSelectableIndex(-1, 1, len(p.Options), p.isSelectable)
 	case EndKey:
-		p.CursorIndex = len(p.Options) - 1
+		p.CursorIndex = nextSelectableIndex(len(p.Options), -1, len(p.Options), p.isSelectable)
 	case SpaceKey:
-		if p.CursorIndex >= 0 && p.CursorIndex < len(p.Options) {
+		if p.CursorIndex >= 0 && p.CursorIndex < len(p.Options) && p.isSelectable(p.CursorIndex) {
 			option := p.Options[p.CursorIndex]
 			if option.IsSelected {
 				option.IsSelected = false
@@ -92,16 +117,29 @@ func (p *MultiSelectPrompt[TValue]) handleKeyPress(key *Key) {
 				return
 			}
 
+			if p.Max > 0 && len(p.Value) >= p.Max {
+				p.blockMax()
+				return
+			}
+
 			option.IsSelected = true
 			p.Value = append(p.Value, option.Value)
 		}
-	case "a":
-		if p.Filter {
+	case "a", "d", "r", "i":
+		switch {
+		case key.Ctrl && key.Name == "a":
+			p.bulkSelect(func(option *MultiSelectOption[TValue]) bool { return true })
+		case key.Ctrl && key.Name == "d":
+			p.bulkSelect(func(option *MultiSelectOption[TValue]) bool { return false })
+		case key.Ctrl && key.Name == "r":
+			p.bulkSelect(func(option *MultiSelectOption[TValue]) bool { return !option.IsSelected })
+		case p.Filter:
 			p.filterOptions(key)
-			return
+		case key.Name == "a":
+			p.selectAll()
+		case key.Name == "i":
+			p.bulkSelect(func(option *MultiSelectOption[TValue]) bool { return !option.IsSelected })
 		}
-
-		p.selectAll()
 	case EnterKey, CancelKey:
 	default:
 		if p.Filter {
@@ -110,20 +148,112 @@ func (p *MultiSelectPrompt[TValue]) handleKeyPress(key *Key) {
 	}
 }
 
+func (p *MultiSelectPrompt[TValue]) isSelectable(index int) bool {
+	return p.Options[index].isSelectable()
+}
+
+// selectAll selects all the options, or deselects them if they are all selected already.
 func (p *MultiSelectPrompt[TValue]) selectAll() {
-	if len(p.Value) == len(p.Options) {
-		p.Value = []TValue{}
-		for _, option := range p.Options {
-			option.IsSelected = false
+	isSelected := true
+	for _, option := range p.Options {
+		if option.isSelectable() && !option.IsSelected {
+			isSelected = false
+			break
+		}
+	}
+	p.bulkSelect(func(option *MultiSelectOption[TValue]) bool { return !isSelected })
+}
+
+// bulkSelect sets whether each of the listed options is selected, leaving the options filtered out as they are.
+// It is blocked with an error if the selection would exceed the Max.
+func (p *MultiSelectPrompt[TValue]) bulkSelect(isSelected func(option *MultiSelectOption[TValue]) bool) {
+	selected := make(map[*MultiSelectOption[TValue]]bool)
+	for _, option := range p.initialOptions {
+		selected[option] = option.IsSelected
+	}
+	for _, option := range p.Options {
+		if option.isSelectable() {
+			selected[option] = isSelected(option)
 		}
+	}
+
+	var value []TValue
+	for _, option := range p.initialOptions {
+		if selected[option] {
+			value = append(value, option.Value)
+		}
+	}
+	if p.Max > 0 && len(value) > p.Max {
+		p.blockMax()
 		return
 	}
 
-	p.Value = make([]TValue, len(p.Options))
-	for i, option := range p.Options {
-		option.IsSelected = true
-		p.Value[i] = option.Value
+	p.Value = append([]TValue{}, value...)
+	for _, option := range p.initialOptions {
+		option.IsSelected = selected[option]
+	}
+}
+
+// blockMax shows the Max error, as a selection exceeding it is blocked.
+func (p *MultiSelectPrompt[TValue]) blockMax() {
+	p.State = ErrorState
+	p.Error = p.maxError().Error()
+}
+
+func (p *MultiSelectPrompt[TValue]) maxError() error {
+	return fmt.Errorf("Please select at most %d options.", p.Max)
+}
+
+// validateCount validates the number of selected options against the Min and Max, before the validate func.
+func (p *MultiSelectPrompt[TValue]) validateCount(validate func(value []TValue) error) func(value []TValue) error {
+	return func(value []TValue) error {
+		if p.Min > 0 && len(value) < p.Min {
+			return fmt.Errorf("Please select at least %d options.", p.Min)
+		}
+		if p.Max > 0 && len(value) > p.Max {
+			return p.maxError()
+		}
+		if validate != nil {
+			return validate(value)
+		}
+		return nil
+	}
+}
+
+func (p *MultiSelectPrompt[TValue]) handleAnswer(answer string) error {
+	if answer == "" {
+		return nil
+	}
+	return p.handleAnswers(splitAnswer(answer))
+}
+
+func (p *MultiSelectPrompt[TValue]) handleAnswers(answers []string) error {
+	selected := make(map[*MultiSelectOption[TValue]]bool)
+	for _, item := range answers {
+		found := false
+		for _, option := range p.initialOptions {
+			if option.isSelectable() && matchAnswer(item, option.Label, option.Value) {
+				selected[option] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("Invalid option: %s", item)
+		}
 	}
+
+	p.Search = ""
+	p.Options = p.initialOptions
+	p.Value = []TValue{}
+	for _, option := range p.Options {
+		option.Matches = nil
+		option.IsSelected = selected[option]
+		if option.IsSelected {
+			p.Value = append(p.Value, option.Value)
+		}
+	}
+	return nil
 }
 
 func (p *MultiSelectPrompt[TValue]) filterOptions(key *Key) {
@@ -132,52 +262,71 @@ func (p *MultiSelectPrompt[TValue]) filterOptions(key *Key) {
 		currentOption = p.Options[p.CursorIndex]
 	}
 
-	p.Search, _ = p.TrackKeyValue(key, p.Search, len(p.Search))
+	p.Search, _ = p.TrackKeyValue(key, p.Search, utils.GraphemeCount(p.Search))
 	p.CursorIndex = 0
+	for _, option := range p.initialOptions {
+		option.Matches = nil
+	}
 
 	if p.Search == "" {
 		p.Options = p.initialOptions
+		p.CursorIndex = nextSelectableIndex(-1, 1, len(p.Options), p.isSelectable)
 		if currentOption == nil {
 			return
 		}
 
 		for i, option := range p.Options {
-			if option.Value == currentOption.Value {
+			if option == currentOption {
 				p.CursorIndex = i
 			}
 		}
 		return
 	}
 
-	p.Options = []*MultiSelectOption[TValue]{}
-	searchRegex, err := regexp.Compile("(?i)" + p.Search)
-	if err != nil {
+	// Only the selectable options are filtered
+	var options []*MultiSelectOption[TValue]
+	for _, option := range p.initialOptions {
+		if option.isSelectable() {
+			options = append(options, option)
+		}
+	}
+	p.Options = filterSearch(options, p.Search, p.RegexFilter,
+		func(option *MultiSelectOption[TValue]) string { return option.Label },
+		func(option *MultiSelectOption[TValue], matches []int) { option.Matches = matches },
+	)
+	if currentOption == nil {
 		return
 	}
-	for _, option := range p.initialOptions {
-		if matched := searchRegex.MatchString(option.Label); matched {
-			p.Options = append(p.Options, option)
-			if currentOption != nil && option.Value == currentOption.Value {
-				p.CursorIndex = len(p.Options) - 1
-			}
+	for i, option := range p.Options {
+		if option == currentOption {
+			p.CursorIndex = i
+			break
 		}
 	}
 }
 
 func mapMultiSelectInitialValue[TValue comparable](value []TValue, options []*MultiSelectOption[TValue]) []TValue {
 	if len(value) > 0 {
+		// The values of disabled options are left out
+		var initialValue []TValue
 		for _, value := range value {
+			isSelectable := true
 			for _, option := range options {
 				if option.Value == value {
-					option.IsSelected = true
+					isSelectable = option.isSelectable()
+					option.IsSelected = isSelectable
 				}
 			}
+			if isSelectable {
+				initialValue = append(initialValue, value)
+			}
 		}
-		return value
+		return initialValue
 	}
 
 	var initialValue []TValue
 	for _, option := range options {
+		option.IsSelected = option.IsSelected && option.isSelectable()
 		if option.IsSelected {
 			initialValue = append(initialValue, option.Value)
 		}
@@ -1,6 +1,7 @@
 package core_test
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/Mist3rBru/go-clack/core"
@@ -231,3 +232,213 @@ func TestMultiSelectInvalidOptionSelection(t *testing.T) {
 	assert.Equal(t, 0, len(p.Options))
 	assert.Equal(t, 0, len(p.Value))
 }
+
+func TestMultiSelectLineAnswer(t *testing.T) {
+	p := core.NewMultiSelectPrompt(core.MultiSelectPromptParams[string]{
+		Input:  NewPipeInput("baz, foo\n"),
+		Output: &bytes.Buffer{},
+		Options: []*core.MultiSelectOption[string]{
+			{Label: "foo"},
+			{Label: "bar", IsSelected: true},
+			{Label: "baz"},
+		},
+		Render: func(p *core.MultiSelectPrompt[string]) string { return "" },
+	})
+
+	value, err := p.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"foo", "baz"}, value)
+	assert.True(t, p.Options[0].IsSelected)
+	assert.False(t, p.Options[1].IsSelected)
+}
+
+func TestMultiSelectFuzzyFilter(t *testing.T) {
+	p := core.NewMultiSelectPrompt(core.MultiSelectPromptParams[string]{
+		Options: []*core.MultiSelectOption[string]{
+			{Label: "typescript"},
+			{Label: "javascript"},
+			{Label: "ts-node"},
+		},
+		Filter: true,
+		Render: func(p *core.MultiSelectPrompt[string]) string { return "" },
+	})
+
+	p.PressKey(&core.Key{Char: "t"})
+	p.PressKey(&core.Key{Char: "s"})
+
+	assert.Equal(t, 2, len(p.Options))
+	assert.Equal(t, "ts-node", p.Options[0].Label)
+	assert.Equal(t, []int{0, 1}, p.Options[0].Matches)
+	assert.Equal(t, "typescript", p.Options[1].Label)
+	assert.Equal(t, []int{0, 4}, p.Options[1].Matches)
+}
+
+func newDisabledMultiSelectPrompt() *core.MultiSelectPrompt[string] {
+	return core.NewMultiSelectPrompt(core.MultiSelectPromptParams[string]{
+		Options: []*core.MultiSelectOption[string]{
+			{Label: "Databases", IsSeparator: true},
+			{Label: "postgres", IsDisabled: true, IsSelected: true},
+			{Label: "mysql"},
+			{Label: "Caches", IsSeparator: true},
+			{Label: "redis"},
+		},
+		Render: func(p *core.MultiSelectPrompt[string]) string { return "" },
+	})
+}
+
+func TestMultiSelectDisabledOptionsCursor(t *testing.T) {
+	p := newDisabledMultiSelectPrompt()
+
+	assert.Equal(t, 2, p.CursorIndex)
+	p.PressKey(&core.Key{Name: core.DownKey})
+	assert.Equal(t, 4, p.CursorIndex)
+	p.PressKey(&core.Key{Name: core.DownKey})
+	assert.Equal(t, 2, p.CursorIndex)
+	p.PressKey(&core.Key{Name: core.UpKey})
+	assert.Equal(t, 4, p.CursorIndex)
+	p.PressKey(&core.Key{Name: core.HomeKey})
+	assert.Equal(t, 2, p.CursorIndex)
+	p.PressKey(&core.Key{Name: core.EndKey})
+	assert.Equal(t, 4, p.CursorIndex)
+}
+
+func TestMultiSelectDisabledOptionsValue(t *testing.T) {
+	p := newDisabledMultiSelectPrompt()
+
+	assert.Empty(t, p.Value)
+	assert.False(t, p.Options[1].IsSelected)
+
+	p.PressKey(&core.Key{Name: "a"})
+	assert.Equal(t, []string{"mysql", "redis"}, p.Value)
+	p.PressKey(&core.Key{Name: "a"})
+	assert.Equal(t, []string{}, p.Value)
+}
+
+func TestMultiSelectRequiredDisabledOptions(t *testing.T) {
+	p := core.NewMultiSelectPrompt(core.MultiSelectPromptParams[string]{
+		Options: []*core.MultiSelectOption[string]{
+			{Label: "foo", IsDisabled: true},
+			{Label: "bar"},
+		},
+		InitialValue: []string{"foo"},
+		Required:     true,
+		Render:       func(p *core.MultiSelectPrompt[string]) string { return "" },
+	})
+
+	assert.Empty(t, p.Value)
+	p.PressKey(&core.Key{Name: core.EnterKey})
+	assert.Equal(t, core.ErrorState, p.State)
+}
+
+func TestMultiSelectFilterDisabledOptions(t *testing.T) {
+	p := newDisabledMultiSelectPrompt()
+	p.Filter = true
+
+	p.PressKey(&core.Key{Char: "s"})
+	assert.Equal(t, 2, len(p.Options))
+	assert.Equal(t, "mysql", p.Options[0].Label)
+	assert.Equal(t, "redis", p.Options[1].Label)
+}
+
+func TestMultiSelectMax(t *testing.T) {
+	p := newMultiSelectPrompt()
+	p.Max = 2
+
+	p.PressKey(&core.Key{Name: core.SpaceKey})
+	p.PressKey(&core.Key{Name: core.DownKey})
+	p.PressKey(&core.Key{Name: core.SpaceKey})
+	p.PressKey(&core.Key{Name: core.DownKey})
+	p.PressKey(&core.Key{Name: core.SpaceKey})
+	assert.Equal(t, []string{"foo", "bar"}, p.Value)
+	assert.False(t, p.Options[2].IsSelected)
+	assert.Equal(t, core.ErrorState, p.State)
+	assert.Equal(t, "Please select at most 2 options.", p.Error)
+
+	p.PressKey(&core.Key{Name: "a", Ctrl: true})
+	assert.Equal(t, []string{"foo", "bar"}, p.Value)
+	assert.Equal(t, core.ErrorState, p.State)
+	assert.Equal(t, "Please select at most 2 options.", p.Error)
+
+	p.PressKey(&core.Key{Name: "a"})
+	assert.Equal(t, []string{"foo", "bar"}, p.Value)
+	assert.Equal(t, core.ErrorState, p.State)
+
+	p.PressKey(&core.Key{Name: core.UpKey})
+	p.PressKey(&core.Key{Name: core.SpaceKey})
+	p.PressKey(&core.Key{Name: core.DownKey})
+	p.PressKey(&core.Key{Name: core.SpaceKey})
+	assert.Equal(t, []string{"foo", "baz"}, p.Value)
+}
+
+func TestMultiSelectMin(t *testing.T) {
+	p := newMultiSelectPrompt()
+	p.Min = 2
+
+	p.PressKey(&core.Key{Name: core.SpaceKey})
+	p.PressKey(&core.Key{Name: core.EnterKey})
+	assert.Equal(t, core.ErrorState, p.State)
+	assert.Equal(t, "Please select at least 2 options.", p.Error)
+
+	p.PressKey(&core.Key{Name: core.DownKey})
+	p.PressKey(&core.Key{Name: core.SpaceKey})
+	p.PressKey(&core.Key{Name: core.EnterKey})
+	assert.Equal(t, core.SubmitState, p.State)
+}
+
+func TestMultiSelectBulkActions(t *testing.T) {
+	p := newMultiSelectPrompt()
+	p.Filter = true
+
+	p.PressKey(&core.Key{Name: "a", Ctrl: true})
+	assert.Equal(t, []string{"foo", "bar", "baz"}, p.Value)
+	p.PressKey(&core.Key{Name: "d", Ctrl: true})
+	assert.Equal(t, []string{}, p.Value)
+
+	p.PressKey(&core.Key{Name: core.SpaceKey})
+	p.PressKey(&core.Key{Name: "r", Ctrl: true})
+	assert.Equal(t, []string{"bar", "baz"}, p.Value)
+	assert.Equal(t, "", p.Search)
+}
+
+func TestMultiSelectBulkActionsOverFilter(t *testing.T) {
+	p := newMultiSelectPrompt()
+	p.Filter = true
+
+	p.PressKey(&core.Key{Name: "b", Char: "b"})
+	p.PressKey(&core.Key{Name: "a", Char: "a"})
+	p.PressKey(&core.Key{Name: "a", Ctrl: true})
+	assert.Equal(t, []string{"bar", "baz"}, p.Value)
+	assert.True(t, p.Options[0].IsSelected)
+
+	p.PressKey(&core.Key{Name: core.BackspaceKey})
+	p.PressKey(&core.Key{Name: core.BackspaceKey})
+	p.PressKey(&core.Key{Name: "r", Ctrl: true})
+	assert.Equal(t, []string{"foo"}, p.Value)
+}
+
+func TestMultiSelectInvertSelection(t *testing.T) {
+	p := newMultiSelectPrompt()
+
+	p.PressKey(&core.Key{Name: core.SpaceKey})
+	p.PressKey(&core.Key{Name: "i", Char: "i"})
+	assert.Equal(t, []string{"bar", "baz"}, p.Value)
+	assert.False(t, p.Options[0].IsSelected)
+}
+
+func TestMultiSelectPromptSubmitListAnswer(t *testing.T) {
+	p := core.NewMultiSelectPrompt(core.MultiSelectPromptParams[string]{
+		Output: &bytes.Buffer{},
+		Options: []*core.MultiSelectOption[string]{
+			{Label: "foo, bar"},
+			{Label: "baz"},
+		},
+		Render: func(p *core.MultiSelectPrompt[string]) string { return "" },
+	})
+
+	value, err := p.SubmitAnswer([]string{"foo, bar"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"foo, bar"}, value)
+
+	_, err = p.SubmitAnswer("foo, bar")
+	assert.ErrorContains(t, err, "Invalid option: foo")
+}
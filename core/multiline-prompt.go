@@ -0,0 +1,165 @@
+package core
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/Mist3rBru/go-clack/core/utils"
+	"github.com/Mist3rBru/go-clack/core/validator"
+)
+
+type MultilinePrompt struct {
+	Prompt[string]
+	Placeholder string
+	Required    bool
+	// SubmitKey is the key chord that submits the value, as Enter inserts a new line
+	SubmitKey *Key
+}
+
+type MultilinePromptParams struct {
+	Input        io.Reader
+	Output       io.Writer
+	InitialValue string
+	Placeholder  string
+	Required     bool
+	SubmitKey    *Key
+	Validate     func(value string) error
+	Render       func(p *MultilinePrompt) string
+}
+
+// MultilineTerminator is the line that ends a multiline answer when the input is not a terminal,
+// unless the input is exhausted first.
+const MultilineTerminator = "."
+
+// NewMultilinePrompt creates a prompt for multiple lines of text, submitted with Ctrl+D by default.
+func NewMultilinePrompt(params MultilinePromptParams) *MultilinePrompt {
+	v := validator.NewValidator("MultilinePrompt")
+	v.ValidateRender(params.Render)
+
+	if params.SubmitKey == nil {
+		params.SubmitKey = &Key{Name: "d", Ctrl: true}
+	}
+
+	var p MultilinePrompt
+	p = MultilinePrompt{
+		Prompt: *NewPrompt(PromptParams[string]{
+			Input:        params.Input,
+			Output:       params.Output,
+			InitialValue: params.InitialValue,
+			CursorIndex:  utils.GraphemeCount(params.InitialValue),
+			Validate:     WrapValidate(params.Validate, &p.Required, "Value is required! Please enter a value."),
+			Render:       WrapRender[string](&p, params.Render),
+		}),
+		Placeholder: params.Placeholder,
+		Required:    params.Required,
+		SubmitKey:   params.SubmitKey,
+	}
+
+	p.IsSubmitKey = p.isSubmitChord
+	p.readAnswer = func(ctx context.Context) (string, error) {
+		return p.readLines(ctx, MultilineTerminator)
+	}
+
+	p.On(KeyEvent, func(args ...any) {
+		p.handleKeyPress(args[0].(*Key))
+	})
+
+	p.On(PasteEvent, func(args ...any) {
+		p.Value, p.CursorIndex = p.TrackPasteValue(args[0].(string), p.Value, p.CursorIndex, true)
+	})
+
+	return &p
+}
+
+// isSubmitChord reports whether the key is the submit chord, as Enter inserts a new line.
+func (p *MultilinePrompt) isSubmitChord(key *Key) bool {
+	return key.Name == p.SubmitKey.Name && key.Ctrl == p.SubmitKey.Ctrl && key.Meta == p.SubmitKey.Meta
+}
+
+func (p *MultilinePrompt) handleKeyPress(key *Key) {
+	if p.isSubmitChord(key) {
+		return
+	}
+
+	row, col := p.CursorPosition()
+	lines := strings.Split(p.Value, "\n")
+
+	switch key.Name {
+	case EnterKey:
+		p.Value, p.CursorIndex = p.TrackKeyValue(&Key{Char: "\n"}, p.Value, p.CursorIndex)
+	case TabKey:
+		if p.Value == "" && p.Placeholder != "" {
+			p.Value = p.Placeholder
+			p.CursorIndex = utils.GraphemeCount(p.Placeholder)
+		}
+	case UpKey:
+		if row > 0 {
+			p.moveCursor(lines, row-1, col)
+		} else {
+			p.CursorIndex = 0
+		}
+	case DownKey:
+		if row < len(lines)-1 {
+			p.moveCursor(lines, row+1, col)
+		} else {
+			p.CursorIndex = utils.GraphemeCount(p.Value)
+		}
+	case HomeKey:
+		p.moveCursor(lines, row, 0)
+	case EndKey:
+		p.moveCursor(lines, row, utils.GraphemeCount(lines[row]))
+	default:
+		p.Value, p.CursorIndex = p.TrackKeyValue(key, p.Value, p.CursorIndex)
+	}
+}
+
+// moveCursor moves the cursor to the column of the line, or to the end of the line if it is shorter.
+func (p *MultilinePrompt) moveCursor(lines []string, row, col int) {
+	index := 0
+	for _, line := range lines[:row] {
+		index += utils.GraphemeCount(line) + 1
+	}
+	p.CursorIndex = index + min(col, utils.GraphemeCount(lines[row]))
+}
+
+// CursorPosition returns the line and column of the cursor.
+func (p *MultilinePrompt) CursorPosition() (row, col int) {
+	for i, grapheme := range utils.Graphemes(p.Value) {
+		if i == p.CursorIndex {
+			break
+		}
+		if grapheme == "\n" {
+			row++
+			col = 0
+		} else {
+			col++
+		}
+	}
+	return row, col
+}
+
+// ValueWithCursor returns the value with the cursor, soft-wrapping long lines with FormatLines and the options,
+// and scrolling with LimitLines to keep the cursor in view.
+func (p *MultilinePrompt) ValueWithCursor(options FormatLinesOptions, usedLines int) string {
+	row, col := p.CursorPosition()
+
+	var rows []string
+	cursorRow := 0
+	for i, line := range strings.Split(p.Value, "\n") {
+		if i == row {
+			graphemes := utils.Graphemes(line)
+			// The cursor's row is the last row of the line wrapped up to the cursor
+			beforeCursor := strings.Join(graphemes[:col], "") + "_"
+			cursorRow = len(rows) + len(p.wrapLine(beforeCursor, options)) - 1
+			line = ValueWithCursor(line, col)
+		}
+		rows = append(rows, p.wrapLine(line, options)...)
+	}
+
+	return p.limitLines(rows, usedLines, cursorRow)
+}
+
+func (p *MultilinePrompt) wrapLine(line string, options FormatLinesOptions) []string {
+	return strings.Split(p.FormatLines([]string{line}, options), "\r\n")
+}
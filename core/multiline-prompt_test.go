@@ -0,0 +1,169 @@
+package core_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Mist3rBru/go-clack/core"
+	"github.com/Mist3rBru/go-clack/third_party/picocolors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newMultilinePrompt(params core.MultilinePromptParams) *core.MultilinePrompt {
+	params.Render = func(p *core.MultilinePrompt) string { return "" }
+	return core.NewMultilinePrompt(params)
+}
+
+func TestMultilinePromptEnterInsertsNewLine(t *testing.T) {
+	p := newMultilinePrompt(core.MultilinePromptParams{})
+
+	p.PressKey(&core.Key{Char: "a"})
+	p.PressKey(&core.Key{Name: core.EnterKey})
+	p.PressKey(&core.Key{Char: "b"})
+
+	assert.Equal(t, "a\nb", p.Value)
+	assert.Equal(t, core.ActiveState, p.State)
+}
+
+func TestMultilinePromptSubmitKey(t *testing.T) {
+	p := newMultilinePrompt(core.MultilinePromptParams{InitialValue: "foo"})
+
+	p.PressKey(&core.Key{Name: "d", Ctrl: true})
+
+	assert.Equal(t, core.SubmitState, p.State)
+	assert.Equal(t, "foo", p.Value)
+}
+
+func TestMultilinePromptCustomSubmitKey(t *testing.T) {
+	p := newMultilinePrompt(core.MultilinePromptParams{
+		InitialValue: "foo",
+		SubmitKey:    &core.Key{Name: core.EnterKey, Meta: true},
+	})
+
+	p.PressKey(&core.Key{Name: "d", Ctrl: true})
+	p.PressKey(&core.Key{Name: core.EnterKey})
+	assert.Equal(t, core.ActiveState, p.State)
+	assert.Equal(t, "foo\n", p.Value)
+
+	p.PressKey(&core.Key{Name: core.EnterKey, Meta: true})
+	assert.Equal(t, core.SubmitState, p.State)
+}
+
+func TestMultilinePromptKeepsPressedKey(t *testing.T) {
+	p := newMultilinePrompt(core.MultilinePromptParams{})
+
+	enter := &core.Key{Name: core.EnterKey}
+	submit := &core.Key{Name: "d", Ctrl: true}
+	p.PressKey(enter)
+	p.PressKey(submit)
+
+	assert.Equal(t, core.Key{Name: core.EnterKey}, *enter)
+	assert.Equal(t, core.Key{Name: "d", Ctrl: true}, *submit)
+	assert.Equal(t, core.SubmitState, p.State)
+}
+
+func TestMultilinePromptLineAnswers(t *testing.T) {
+	input := NewPipeInput("foo\nbar\n.\nbaz\nqux\n")
+	newLinePrompt := func() *core.MultilinePrompt {
+		return core.NewMultilinePrompt(core.MultilinePromptParams{
+			Input:  input,
+			Output: &bytes.Buffer{},
+			Render: func(p *core.MultilinePrompt) string { return "" },
+		})
+	}
+
+	value, err := newLinePrompt().Run()
+	assert.NoError(t, err)
+	assert.Equal(t, "foo\nbar", value)
+
+	value, err = newLinePrompt().Run()
+	assert.NoError(t, err)
+	assert.Equal(t, "baz\nqux", value)
+}
+
+func TestMultilinePromptRequired(t *testing.T) {
+	p := newMultilinePrompt(core.MultilinePromptParams{Required: true})
+
+	p.PressKey(&core.Key{Name: "d", Ctrl: true})
+
+	assert.Equal(t, core.ErrorState, p.State)
+	assert.Equal(t, "Value is required! Please enter a value.", p.Error)
+}
+
+func TestMultilinePromptCursorNavigation(t *testing.T) {
+	p := newMultilinePrompt(core.MultilinePromptParams{InitialValue: "first line\nab\nthird line"})
+	row, col := p.CursorPosition()
+	assert.Equal(t, 2, row)
+	assert.Equal(t, 10, col)
+
+	p.PressKey(&core.Key{Name: core.UpKey})
+	row, col = p.CursorPosition()
+	assert.Equal(t, 1, row)
+	assert.Equal(t, 2, col)
+
+	p.PressKey(&core.Key{Name: core.UpKey})
+	row, col = p.CursorPosition()
+	assert.Equal(t, 0, row)
+	assert.Equal(t, 2, col)
+
+	p.PressKey(&core.Key{Name: core.EndKey})
+	assert.Equal(t, 10, p.CursorIndex)
+
+	p.PressKey(&core.Key{Name: core.RightKey})
+	row, col = p.CursorPosition()
+	assert.Equal(t, 1, row)
+	assert.Equal(t, 0, col)
+
+	p.PressKey(&core.Key{Name: core.DownKey})
+	p.PressKey(&core.Key{Name: core.HomeKey})
+	row, col = p.CursorPosition()
+	assert.Equal(t, 2, row)
+	assert.Equal(t, 0, col)
+
+	p.PressKey(&core.Key{Name: core.DownKey})
+	assert.Equal(t, len(p.Value), p.CursorIndex)
+
+	p.PressKey(&core.Key{Name: core.BackspaceKey})
+	assert.Equal(t, "first line\nab\nthird lin", p.Value)
+}
+
+func TestMultilinePromptPaste(t *testing.T) {
+	p := newMultilinePrompt(core.MultilinePromptParams{})
+
+	p.PressKey(&core.Key{Name: core.PasteKey, Char: "foo\r\nbar"})
+
+	assert.Equal(t, "foo\nbar", p.Value)
+	assert.Equal(t, 7, p.CursorIndex)
+}
+
+func TestMultilinePromptValueWithCursor(t *testing.T) {
+	p := newMultilinePrompt(core.MultilinePromptParams{InitialValue: "foo\nbar"})
+	p.CursorIndex = 1
+
+	expected := "f" + picocolors.Inverse("o") + "o\nbar"
+	assert.Equal(t, expected, p.ValueWithCursor(core.FormatLinesOptions{}, 0))
+}
+
+func TestMultilinePromptSoftWrap(t *testing.T) {
+	p := newMultilinePrompt(core.MultilinePromptParams{InitialValue: "lorem ipsum dolor sit amet"})
+
+	value := p.ValueWithCursor(core.FormatLinesOptions{MaxWidth: 12}, 0)
+
+	assert.Equal(t, []string{"lorem ipsum", "dolor sit", "amet" + picocolors.Inverse(" ")}, strings.Split(value, "\n"))
+}
+
+func TestMultilinePromptScroll(t *testing.T) {
+	lines := make([]string, 30)
+	for i := range lines {
+		lines[i] = string(rune('a' + i%26))
+	}
+	p := newMultilinePrompt(core.MultilinePromptParams{InitialValue: strings.Join(lines, "\n")})
+
+	rows := strings.Split(p.ValueWithCursor(core.FormatLinesOptions{}, 0), "\n")
+
+	assert.Len(t, rows, 10)
+	assert.Equal(t, "...", rows[0])
+	assert.Equal(t, "d"+picocolors.Inverse(" "), rows[9])
+}
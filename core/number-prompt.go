@@ -0,0 +1,343 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/Mist3rBru/go-clack/core/utils"
+	"github.com/Mist3rBru/go-clack/core/validator"
+)
+
+// Number is the set of types a NumberPrompt can return.
+type Number interface {
+	int | int64 | float64
+}
+
+type NumberPrompt[T Number] struct {
+	Prompt[T]
+	// Text is the typed number, without thousands separators
+	Text      string
+	Min       *T
+	Max       *T
+	Step      T
+	Separator string
+	Unit      string
+	Required  bool
+
+	// replaceText is set while the initial text is untouched, so typing replaces it
+	replaceText bool
+}
+
+type NumberPromptParams[T Number] struct {
+	Input        io.Reader
+	Output       io.Writer
+	InitialValue T
+	Min          *T
+	Max          *T
+	Step         T
+	Separator    string
+	Unit         string
+	Required     bool
+	Validate     func(value T) error
+	Render       func(p *NumberPrompt[T]) string
+}
+
+func NewNumberPrompt[T Number](params NumberPromptParams[T]) *NumberPrompt[T] {
+	v := validator.NewValidator("NumberPrompt")
+	v.ValidateRender(params.Render)
+
+	if params.Min != nil && params.Max != nil && *params.Min > *params.Max {
+		v.Panic("Min must be less than or equal to Max")
+	}
+	if params.Step < 0 {
+		v.Panic("Step must be positive")
+	}
+	if params.Step == 0 {
+		params.Step = 1
+	}
+
+	// A zero initial value starts empty, so Required fails until a number is typed
+	text := ""
+	if params.InitialValue != 0 {
+		text = formatNumber(params.InitialValue)
+	}
+
+	var p NumberPrompt[T]
+	p = NumberPrompt[T]{
+		Prompt: *NewPrompt(PromptParams[T]{
+			Input:        params.Input,
+			Output:       params.Output,
+			InitialValue: params.InitialValue,
+			CursorIndex:  len(text),
+			ParseAnswer:  p.handleAnswer,
+			Render:       WrapRender[T](&p, params.Render),
+		}),
+		Text:      text,
+		Min:       params.Min,
+		Max:       params.Max,
+		Step:      params.Step,
+		Separator: params.Separator,
+		Unit:      params.Unit,
+		Required:  params.Required,
+
+		replaceText: text != "",
+	}
+	p.Validate = func(value T) error {
+		if err := p.validate(value); err != nil {
+			return err
+		}
+		if params.Validate != nil {
+			return params.Validate(value)
+		}
+		return nil
+	}
+
+	p.On(KeyEvent, func(args ...any) {
+		p.handleKeyPress(args[0].(*Key))
+	})
+
+	p.On(PasteEvent, func(args ...any) {
+		text := strings.ReplaceAll(args[0].(string), " ", "")
+		if p.Separator != "" {
+			text = strings.ReplaceAll(text, p.Separator, "")
+		}
+		p.insertText(text)
+		p.replaceText = false
+	})
+
+	return &p
+}
+
+func (p *NumberPrompt[T]) handleKeyPress(key *Key) {
+	defer func() { p.replaceText = false }()
+
+	switch key.Name {
+	case UpKey:
+		p.increment(p.Step)
+	case DownKey:
+		p.increment(-p.Step)
+	case BackspaceKey, DeleteKey, HomeKey, EndKey, LeftKey, RightKey:
+		p.Text, p.CursorIndex = p.TrackKeyValue(key, p.Text, p.CursorIndex)
+		p.parseText()
+	default:
+		if key.Char != "" && !key.Ctrl && !key.Meta {
+			p.insertText(key.Char)
+		}
+	}
+}
+
+// insertText inserts text at the cursor, or replaces the untouched initial text,
+// rejecting it unless it keeps the number well-formed:
+// digits, a leading minus sign if negative numbers are allowed, and a single decimal point for floats.
+func (p *NumberPrompt[T]) insertText(text string) {
+	if text == "" {
+		return
+	}
+
+	newText, cursorIndex := p.Text[:p.CursorIndex]+text+p.Text[p.CursorIndex:], p.CursorIndex+len(text)
+	if p.replaceText {
+		newText, cursorIndex = text, len(text)
+	}
+	if !p.isPartialNumber(newText) {
+		return
+	}
+
+	p.Text = newText
+	p.CursorIndex = cursorIndex
+	p.parseText()
+}
+
+// isPartialNumber reports whether the text is a number, or the start of one, that the prompt accepts.
+func (p *NumberPrompt[T]) isPartialNumber(text string) bool {
+	digits := strings.TrimPrefix(text, "-")
+	if len(digits) < len(text) && p.Min != nil && *p.Min >= 0 {
+		return false
+	}
+
+	hasPoint := false
+	for _, r := range digits {
+		switch {
+		case r >= '0' && r <= '9':
+		case r == '.' && isFloat[T]() && !hasPoint:
+			hasPoint = true
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// parseText updates the value from the typed text, if it is a complete number.
+func (p *NumberPrompt[T]) parseText() {
+	if p.Text == "" {
+		var zero T
+		p.Value = zero
+		return
+	}
+	if value, err := parseNumber[T](p.Text); err == nil {
+		p.Value = value
+	}
+}
+
+// increment adds the delta to the value, clamped by Min and Max.
+func (p *NumberPrompt[T]) increment(delta T) {
+	value := p.Value + delta
+	if isFloat[T]() {
+		// Round to the step's precision, so repeated float steps do not accumulate errors
+		decimals := 0
+		if _, fraction, ok := strings.Cut(formatNumber(p.Step), "."); ok {
+			decimals = len(fraction)
+		}
+		pow := math.Pow(10, float64(decimals))
+		value = T(math.Round(float64(value)*pow) / pow)
+	}
+
+	if p.Min != nil && value < *p.Min {
+		value = *p.Min
+	}
+	if p.Max != nil && value > *p.Max {
+		value = *p.Max
+	}
+
+	p.Value = value
+	p.Text = formatNumber(value)
+	p.CursorIndex = len(p.Text)
+}
+
+func (p *NumberPrompt[T]) validate(value T) error {
+	if p.Text == "" {
+		if p.Required {
+			return errors.New("Value is required! Please enter a value.")
+		}
+		return nil
+	}
+
+	if _, err := parseNumber[T](p.Text); err != nil {
+		return errInvalidNumber[T]()
+	}
+
+	switch {
+	case p.Min != nil && p.Max != nil && (value < *p.Min || value > *p.Max):
+		return fmt.Errorf("Please enter a number between %s and %s.", p.Format(*p.Min), p.Format(*p.Max))
+	case p.Min != nil && value < *p.Min:
+		return fmt.Errorf("Please enter a number greater than or equal to %s.", p.Format(*p.Min))
+	case p.Max != nil && value > *p.Max:
+		return fmt.Errorf("Please enter a number less than or equal to %s.", p.Format(*p.Max))
+	}
+	return nil
+}
+
+func (p *NumberPrompt[T]) handleAnswer(answer string) error {
+	answer = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(answer), p.Unit))
+	if answer == "" {
+		return nil
+	}
+	if p.Separator != "" {
+		answer = strings.ReplaceAll(answer, p.Separator, "")
+	}
+
+	value, err := parseNumber[T](answer)
+	if err != nil {
+		return errInvalidNumber[T]()
+	}
+
+	p.Text = answer
+	p.Value = value
+	p.CursorIndex = len(answer)
+	return nil
+}
+
+// Format formats a number with the thousands separator and the unit.
+func (p *NumberPrompt[T]) Format(value T) string {
+	text, _ := separateThousands(formatNumber(value), 0, p.Separator)
+	if p.Unit != "" {
+		text += " " + p.Unit
+	}
+	return text
+}
+
+// FormattedValue returns the typed number with the thousands separator and the unit.
+func (p *NumberPrompt[T]) FormattedValue() string {
+	text, _ := separateThousands(p.Text, 0, p.Separator)
+	if p.Unit != "" && text != "" {
+		text += " " + p.Unit
+	}
+	return text
+}
+
+// ValueWithCursor returns the typed number with the thousands separator, the cursor and the unit.
+func (p *NumberPrompt[T]) ValueWithCursor() string {
+	text, cursorIndex := separateThousands(p.Text, p.CursorIndex, p.Separator)
+	value := ValueWithCursor(text, cursorIndex)
+	if p.Unit != "" {
+		value += " " + p.Unit
+	}
+	return value
+}
+
+// separateThousands inserts the separator between each group of three digits of the integer part,
+// moving the cursor index along.
+func separateThousands(text string, cursorIndex int, separator string) (string, int) {
+	if separator == "" {
+		return text, cursorIndex
+	}
+
+	sign := ""
+	if strings.HasPrefix(text, "-") {
+		sign = "-"
+	}
+	integer, fraction, hasPoint := strings.Cut(text[len(sign):], ".")
+
+	var sb strings.Builder
+	sb.WriteString(sign)
+	newCursorIndex := cursorIndex
+	for i, r := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			sb.WriteString(separator)
+			if len(sign)+i <= cursorIndex {
+				newCursorIndex += utils.GraphemeCount(separator)
+			}
+		}
+		sb.WriteRune(r)
+	}
+	if hasPoint {
+		sb.WriteString("." + fraction)
+	}
+	return sb.String(), newCursorIndex
+}
+
+func errInvalidNumber[T Number]() error {
+	if isFloat[T]() {
+		return errors.New("Please enter a valid number.")
+	}
+	return errors.New("Please enter a valid integer.")
+}
+
+func isFloat[T Number]() bool {
+	_, ok := any(T(0)).(float64)
+	return ok
+}
+
+func parseNumber[T Number](text string) (T, error) {
+	switch any(T(0)).(type) {
+	case float64:
+		n, err := strconv.ParseFloat(text, 64)
+		return T(n), err
+	case int:
+		n, err := strconv.ParseInt(text, 10, strconv.IntSize)
+		return T(n), err
+	}
+	n, err := strconv.ParseInt(text, 10, 64)
+	return T(n), err
+}
+
+func formatNumber[T Number](value T) string {
+	if isFloat[T]() {
+		return strconv.FormatFloat(float64(value), 'f', -1, 64)
+	}
+	return strconv.FormatInt(int64(value), 10)
+}
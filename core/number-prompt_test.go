@@ -0,0 +1,222 @@
+package core_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Mist3rBru/go-clack/core"
+	"github.com/Mist3rBru/go-clack/third_party/picocolors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newNumberPrompt[T core.Number](params core.NumberPromptParams[T]) *core.NumberPrompt[T] {
+	params.Render = func(p *core.NumberPrompt[T]) string { return "" }
+	return core.NewNumberPrompt(params)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestNumberPromptInitialValue(t *testing.T) {
+	p := newNumberPrompt(core.NumberPromptParams[int]{InitialValue: 42})
+
+	assert.Equal(t, 42, p.Value)
+	assert.Equal(t, "42", p.Text)
+	assert.Equal(t, 2, p.CursorIndex)
+}
+
+func TestNumberPromptInvalidParams(t *testing.T) {
+	assert.Panics(t, func() {
+		newNumberPrompt(core.NumberPromptParams[int]{Min: ptr(10), Max: ptr(1)})
+	})
+	assert.Panics(t, func() {
+		newNumberPrompt(core.NumberPromptParams[int]{Step: -1})
+	})
+}
+
+func TestNumberPromptTypeValue(t *testing.T) {
+	p := newNumberPrompt(core.NumberPromptParams[int]{})
+	p.PressKey(&core.Key{Name: core.BackspaceKey})
+
+	for _, char := range []string{"1", "a", "2", ".", " ", "3"} {
+		p.PressKey(&core.Key{Char: char, Name: core.KeyName(char)})
+	}
+
+	assert.Equal(t, "123", p.Text)
+	assert.Equal(t, 123, p.Value)
+
+	p.PressKey(&core.Key{Name: core.HomeKey})
+	p.PressKey(&core.Key{Char: "-"})
+	assert.Equal(t, -123, p.Value)
+
+	p.PressKey(&core.Key{Char: "-"})
+	assert.Equal(t, "-123", p.Text)
+
+	p.PressKey(&core.Key{Name: core.BackspaceKey})
+	assert.Equal(t, 123, p.Value)
+}
+
+func TestNumberPromptTypeFloat(t *testing.T) {
+	p := newNumberPrompt(core.NumberPromptParams[float64]{})
+	p.PressKey(&core.Key{Name: core.BackspaceKey})
+
+	for _, char := range []string{"1", ".", "5", ".", "2"} {
+		p.PressKey(&core.Key{Char: char})
+	}
+
+	assert.Equal(t, "1.52", p.Text)
+	assert.Equal(t, 1.52, p.Value)
+}
+
+func TestNumberPromptRejectNegativeWithPositiveMin(t *testing.T) {
+	p := newNumberPrompt(core.NumberPromptParams[int]{InitialValue: 3, Min: ptr(0)})
+	p.PressKey(&core.Key{Name: core.HomeKey})
+
+	p.PressKey(&core.Key{Char: "-"})
+
+	assert.Equal(t, "3", p.Text)
+}
+
+func TestNumberPromptTypeWithoutInitialValue(t *testing.T) {
+	p := newNumberPrompt(core.NumberPromptParams[int]{})
+	assert.Equal(t, "", p.Text)
+
+	p.PressKey(&core.Key{Char: "5"})
+
+	assert.Equal(t, "5", p.Text)
+	assert.Equal(t, 5, p.Value)
+}
+
+func TestNumberPromptTypeReplacesInitialValue(t *testing.T) {
+	p := newNumberPrompt(core.NumberPromptParams[int]{InitialValue: 42})
+	assert.Equal(t, "42", p.Text)
+
+	p.PressKey(&core.Key{Char: "7"})
+	p.PressKey(&core.Key{Char: "1"})
+
+	assert.Equal(t, "71", p.Text)
+	assert.Equal(t, 71, p.Value)
+}
+
+func TestNumberPromptIncrement(t *testing.T) {
+	p := newNumberPrompt(core.NumberPromptParams[int]{
+		InitialValue: 8,
+		Step:         5,
+		Min:          ptr(0),
+		Max:          ptr(20),
+	})
+
+	p.PressKey(&core.Key{Name: core.UpKey})
+	assert.Equal(t, 13, p.Value)
+	assert.Equal(t, "13", p.Text)
+
+	p.PressKey(&core.Key{Name: core.UpKey})
+	p.PressKey(&core.Key{Name: core.UpKey})
+	assert.Equal(t, 20, p.Value)
+
+	for range 5 {
+		p.PressKey(&core.Key{Name: core.DownKey})
+	}
+	assert.Equal(t, 0, p.Value)
+	assert.Equal(t, 1, p.CursorIndex)
+}
+
+func TestNumberPromptIncrementFloat(t *testing.T) {
+	p := newNumberPrompt(core.NumberPromptParams[float64]{Step: 0.1})
+
+	for range 3 {
+		p.PressKey(&core.Key{Name: core.UpKey})
+	}
+
+	assert.Equal(t, 0.3, p.Value)
+	assert.Equal(t, "0.3", p.Text)
+}
+
+func TestNumberPromptPaste(t *testing.T) {
+	p := newNumberPrompt(core.NumberPromptParams[int64]{Separator: ","})
+	p.PressKey(&core.Key{Name: core.BackspaceKey})
+
+	p.PressKey(&core.Key{Name: core.PasteKey, Char: "1,234,567"})
+	assert.Equal(t, int64(1234567), p.Value)
+
+	p.PressKey(&core.Key{Name: core.PasteKey, Char: "abc"})
+	assert.Equal(t, "1234567", p.Text)
+}
+
+func TestNumberPromptValueWithCursor(t *testing.T) {
+	p := newNumberPrompt(core.NumberPromptParams[float64]{
+		InitialValue: -1234567.5,
+		Separator:    ",",
+		Unit:         "ms",
+	})
+
+	assert.Equal(t, "-1,234,567.5"+picocolors.Inverse(" ")+" ms", p.ValueWithCursor())
+	assert.Equal(t, "-1,234,567.5 ms", p.FormattedValue())
+
+	p.CursorIndex = 2
+	assert.Equal(t, "-1,"+picocolors.Inverse("2")+"34,567.5 ms", p.ValueWithCursor())
+
+	p.CursorIndex = 1
+	assert.Equal(t, "-"+picocolors.Inverse("1")+",234,567.5 ms", p.ValueWithCursor())
+}
+
+func TestNumberPromptValidate(t *testing.T) {
+	p := newNumberPrompt(core.NumberPromptParams[int]{
+		InitialValue: 5,
+		Min:          ptr(1),
+		Max:          ptr(10),
+	})
+
+	p.PressKey(&core.Key{Char: "0"})
+	p.PressKey(&core.Key{Name: core.EnterKey})
+	assert.Equal(t, core.ErrorState, p.State)
+	assert.Equal(t, "Please enter a number between 1 and 10.", p.Error)
+
+	p.PressKey(&core.Key{Name: core.BackspaceKey})
+	p.PressKey(&core.Key{Char: "5"})
+	p.PressKey(&core.Key{Name: core.EnterKey})
+	assert.Equal(t, core.SubmitState, p.State)
+	assert.Equal(t, 5, p.Value)
+}
+
+func TestNumberPromptValidateIncomplete(t *testing.T) {
+	p := newNumberPrompt(core.NumberPromptParams[int]{})
+	p.PressKey(&core.Key{Name: core.BackspaceKey})
+
+	p.PressKey(&core.Key{Char: "-"})
+	p.PressKey(&core.Key{Name: core.EnterKey})
+
+	assert.Equal(t, core.ErrorState, p.State)
+	assert.Equal(t, "Please enter a valid integer.", p.Error)
+}
+
+func TestNumberPromptRequired(t *testing.T) {
+	p := newNumberPrompt(core.NumberPromptParams[int]{Required: true})
+
+	p.PressKey(&core.Key{Name: core.EnterKey})
+
+	assert.Equal(t, core.ErrorState, p.State)
+	assert.Equal(t, "Value is required! Please enter a value.", p.Error)
+}
+
+func TestNumberPromptSubmitAnswer(t *testing.T) {
+	p := core.NewNumberPrompt(core.NumberPromptParams[int]{
+		Output:    &bytes.Buffer{},
+		Separator: ",",
+		Unit:      "ms",
+		Max:       ptr(5000),
+		Render:    func(p *core.NumberPrompt[int]) string { return p.FormattedValue() },
+	})
+
+	value, err := p.SubmitAnswer("1,500 ms")
+	assert.NoError(t, err)
+	assert.Equal(t, 1500, value)
+
+	_, err = p.SubmitAnswer("fifteen")
+	assert.ErrorIs(t, err, core.ErrInvalidAnswer)
+
+	_, err = p.SubmitAnswer("6000")
+	assert.ErrorContains(t, err, "Please enter a number less than or equal to 5,000 ms.")
+}
@@ -1,11 +1,11 @@
 package core
 
 import (
-	"os"
+	"io"
 	"strings"
 
+	"github.com/Mist3rBru/go-clack/core/utils"
 	"github.com/Mist3rBru/go-clack/core/validator"
-	"github.com/Mist3rBru/go-clack/third_party/picocolors"
 )
 
 type PasswordPrompt struct {
@@ -14,8 +14,8 @@ type PasswordPrompt struct {
 }
 
 type PasswordPromptParams struct {
-	Input        *os.File
-	Output       *os.File
+	Input        io.Reader
+	Output       io.Writer
 	InitialValue string
 	Required     bool
 	Validate     func(value string) error
@@ -32,7 +32,7 @@ func NewPasswordPrompt(params PasswordPromptParams) *PasswordPrompt {
 			Input:        params.Input,
 			Output:       params.Output,
 			InitialValue: params.InitialValue,
-			CursorIndex:  len(params.InitialValue),
+			CursorIndex:  utils.GraphemeCount(params.InitialValue),
 			Validate:     WrapValidate(params.Validate, &p.Required, "Password is required! Please enter a value."),
 			Render:       WrapRender[string](&p, params.Render),
 		}),
@@ -43,17 +43,17 @@ func NewPasswordPrompt(params PasswordPromptParams) *PasswordPrompt {
 		p.Value, p.CursorIndex = p.TrackKeyValue(args[0].(*Key), p.Value, p.CursorIndex)
 	})
 
+	p.On(PasteEvent, func(args ...any) {
+		p.Value, p.CursorIndex = p.TrackPasteValue(args[0].(string), p.Value, p.CursorIndex, false)
+	})
+
 	return &p
 }
 
 func (p *PasswordPrompt) ValueWithMask() string {
-	return strings.Repeat("*", len(p.Value))
+	return strings.Repeat("*", utils.GraphemeCount(p.Value))
 }
 
 func (p *PasswordPrompt) ValueWithMaskAndCursor() string {
-	maskedValue := strings.Repeat("*", len(p.Value))
-	if p.CursorIndex == len(p.Value) {
-		return maskedValue + picocolors.Inverse(" ")
-	}
-	return maskedValue[0:p.CursorIndex] + picocolors.Inverse(string(maskedValue[p.CursorIndex])) + maskedValue[p.CursorIndex+1:]
+	return ValueWithCursor(p.ValueWithMask(), p.CursorIndex)
 }
@@ -64,6 +64,16 @@ func TestPasswordMaskWithCursor(t *testing.T) {
 	assert.Equal(t, "*", p.ValueWithMaskAndCursor())
 }
 
+func TestPasswordMaskUnicode(t *testing.T) {
+	p := core.NewPasswordPrompt(core.PasswordPromptParams{
+		InitialValue: "çã日",
+		Render:       func(p *core.PasswordPrompt) string { return "" },
+	})
+
+	assert.Equal(t, "***", p.ValueWithMask())
+	assert.Equal(t, "*** ", p.ValueWithMaskAndCursor())
+}
+
 func TestValidatePassword(t *testing.T) {
 	p := core.NewPasswordPrompt(core.PasswordPromptParams{
 		InitialValue: "123",
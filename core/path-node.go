@@ -2,7 +2,6 @@ package core
 
 import (
 	"path"
-	"regexp"
 	"sort"
 	"strings"
 
@@ -21,13 +20,18 @@ type PathNode struct {
 	Children []*PathNode
 
 	IsSelected bool
+	// Matches are the grapheme indexes of the name matched by the search
+	Matches []int
 
 	FileSystem  FileSystem
 	OnlyShowDir bool
+	// RegexFilter matches the search as a regular expression, instead of fuzzily
+	RegexFilter bool
 }
 
 type PathNodeOptions struct {
 	OnlyShowDir bool
+	RegexFilter bool
 	FileSystem  FileSystem
 }
 
@@ -42,6 +46,7 @@ func NewPathNode(rootPath string, options PathNodeOptions) *PathNode {
 		IsDir: true,
 
 		OnlyShowDir: options.OnlyShowDir,
+		RegexFilter: options.RegexFilter,
 		FileSystem:  options.FileSystem,
 	}
 	root.Open()
@@ -72,6 +77,7 @@ func (p *PathNode) Open() {
 
 			FileSystem:  p.FileSystem,
 			OnlyShowDir: p.OnlyShowDir,
+			RegexFilter: p.RegexFilter,
 		})
 	}
 
@@ -117,23 +123,38 @@ func (p *PathNode) Flat() []*PathNode {
 	return options
 }
 
+// FilteredFlat returns the open nodes in tree order, with the current node's layers filtered by the search
+// and ranked by how well they match it.
 func (p *PathNode) FilteredFlat(search string, currentNode *PathNode) []*PathNode {
-	searchRegex, err := regexp.Compile("(?i)" + search)
-	if err != nil || search == "" {
-		return p.Flat()
+	if search == "" {
+		options := p.Flat()
+		for _, node := range options {
+			node.Matches = nil
+		}
+		return options
 	}
 
 	var options []*PathNode
-	p.TraverseNodes(func(node *PathNode) {
-		if node.Depth == currentNode.Depth && node.Depth > 0 {
-			if matched := searchRegex.MatchString(node.Name); matched {
-				options = append(options, node)
-			}
-		} else {
-			options = append(options, node)
+	var traverse func(node *PathNode)
+	traverse = func(node *PathNode) {
+		options = append(options, node)
+		if node.Depth != currentNode.Depth {
+			node.Matches = nil
+		}
+		if !node.IsDir {
+			return
 		}
-	})
 
+		children := node.Children
+		if node.Depth+1 == currentNode.Depth {
+			children = node.filterChildren(search)
+		}
+		for _, child := range children {
+			traverse(child)
+		}
+	}
+
+	traverse(p)
 	return options
 }
 
@@ -145,20 +166,27 @@ func (p *PathNode) Layer() []*PathNode {
 	return p.Parent.Children
 }
 
+// FilteredLayer returns the node's siblings that match the search, ranked by how well they match it.
 func (p *PathNode) FilteredLayer(search string) []*PathNode {
-	searchRegex, err := regexp.Compile("(?i)" + search)
-	if err != nil || search == "" {
+	if p.IsRoot() || search == "" {
+		for _, node := range p.Layer() {
+			node.Matches = nil
+		}
 		return p.Layer()
 	}
 
-	var layer []*PathNode
-	for _, node := range p.Layer() {
-		if matched := searchRegex.MatchString(node.Name); matched {
-			layer = append(layer, node)
-		}
+	return p.Parent.filterChildren(search)
+}
+
+func (p *PathNode) filterChildren(search string) []*PathNode {
+	for _, child := range p.Children {
+		child.Matches = nil
 	}
 
-	return layer
+	return filterSearch(p.Children, search, p.RegexFilter,
+		func(node *PathNode) string { return node.Name },
+		func(node *PathNode, matches []int) { node.Matches = matches },
+	)
 }
 
 func (p *PathNode) FirstChild() *PathNode {
@@ -189,6 +217,14 @@ func (p *PathNode) NextChild(index int) *PathNode {
 	return p.Children[index+1]
 }
 
+// resolvePath resolves a path relative to the node, unless it is absolute.
+func (p *PathNode) resolvePath(target string) string {
+	if path.IsAbs(target) {
+		return path.Clean(target)
+	}
+	return path.Join(p.Path, target)
+}
+
 func (p *PathNode) IsRoot() bool {
 	return p.Parent == nil
 }
@@ -85,6 +85,33 @@ func TestPathNodeFilteredFlat(t *testing.T) {
 	assert.Equal(t, 2, len(options))
 }
 
+func TestPathNodeFilteredLayer(t *testing.T) {
+	root := newPathNode("/root/go-clack/core")
+	dir, file := root.Children[0], root.Children[1]
+
+	assert.Equal(t, []*core.PathNode{dir, file}, dir.FilteredLayer("i"))
+	assert.Equal(t, []int{1}, dir.Matches)
+	assert.Equal(t, []int{1}, file.Matches)
+
+	assert.Equal(t, []*core.PathNode{file}, dir.FilteredLayer("fle"))
+	assert.Equal(t, []int{0, 2, 3}, file.Matches)
+
+	assert.Empty(t, dir.FilteredLayer("("))
+}
+
+func TestPathNodeFilteredLayerRegex(t *testing.T) {
+	root := core.NewPathNode("/root/go-clack/core", core.PathNodeOptions{
+		RegexFilter: true,
+		FileSystem:  MockFileSystem{},
+	})
+	dir, file := root.Children[0], root.Children[1]
+
+	assert.Equal(t, []*core.PathNode{dir, file}, dir.FilteredLayer("i"))
+	assert.Equal(t, []*core.PathNode{file}, dir.FilteredLayer("^f.l"))
+	assert.Equal(t, []int{0, 1, 2}, file.Matches)
+	assert.Empty(t, dir.FilteredLayer("fle"))
+}
+
 func TestPathNodeIndexOf(t *testing.T) {
 	node := newPathNode("/root/go-clack/core")
 	node.Open()
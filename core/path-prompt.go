@@ -1,7 +1,7 @@
 package core
 
 import (
-	"os"
+	"io"
 	"regexp"
 	"strings"
 
@@ -22,8 +22,8 @@ type PathPrompt struct {
 }
 
 type PathPromptParams struct {
-	Input        *os.File
-	Output       *os.File
+	Input        io.Reader
+	Output       io.Writer
 	InitialValue string
 	OnlyShowDir  bool
 	Required     bool
@@ -46,7 +46,7 @@ func NewPathPrompt(params PathPromptParams) *PathPrompt {
 			Input:        params.Input,
 			Output:       params.Output,
 			InitialValue: params.InitialValue,
-			CursorIndex:  len(params.InitialValue),
+			CursorIndex:  utils.GraphemeCount(params.InitialValue),
 			Validate:     WrapValidate(params.Validate, &p.Required, "Path does not exist! Please enter a valid path."),
 			Render:       WrapRender[string](&p, params.Render),
 		}),
@@ -59,7 +59,7 @@ func NewPathPrompt(params PathPromptParams) *PathPrompt {
 	if cwd, err := p.FileSystem.Getwd(); err == nil && params.InitialValue == "" {
 		p.Prompt.Value = cwd
 		p.Value = cwd
-		p.CursorIndex = len(cwd)
+		p.CursorIndex = utils.GraphemeCount(cwd)
 	}
 	p.changeHint()
 
@@ -67,6 +67,11 @@ func NewPathPrompt(params PathPromptParams) *PathPrompt {
 		p.handleKeyPress(args[0].(*Key))
 	})
 
+	p.On(PasteEvent, func(args ...any) {
+		p.Value, p.CursorIndex = p.TrackPasteValue(args[0].(string), p.Value, p.CursorIndex, false)
+		p.changeHint()
+	})
+
 	return &p
 }
 
@@ -119,30 +124,20 @@ func (p *PathPrompt) changeHint() {
 }
 
 func (p *PathPrompt) ValueWithCursor() string {
-	var (
-		value string
-		hint  string
-	)
-	if p.CursorIndex >= len(p.Value) {
-		value = p.Value
-		if p.Hint == "" {
-			hint = picocolors.Inverse(" ")
-		} else {
-			hint = picocolors.Inverse(string(p.Hint[0])) + picocolors.Dim(p.Hint[1:])
-		}
-	} else {
-		s1 := p.Value[0:p.CursorIndex]
-		s2 := p.Value[p.CursorIndex:]
-		value = s1 + picocolors.Inverse(string(s2[0])) + s2[1:]
-		hint = picocolors.Dim(p.Hint)
+	if p.CursorIndex < utils.GraphemeCount(p.Value) {
+		return ValueWithCursor(p.Value, p.CursorIndex) + picocolors.Dim(p.Hint)
+	}
+	if p.Hint == "" {
+		return p.Value + picocolors.Inverse(" ")
 	}
-	return value + hint
+	hint := utils.Graphemes(p.Hint)
+	return p.Value + picocolors.Inverse(hint[0]) + picocolors.Dim(strings.Join(hint[1:], ""))
 }
 
 func (p *PathPrompt) completeValue() {
 	p.Value += p.Hint
 	p.Prompt.Value = p.Value
-	p.CursorIndex = len(p.Value)
+	p.CursorIndex = utils.GraphemeCount(p.Value)
 	p.Hint = ""
 	p.HintOptions = []string{}
 	p.changeHint()
@@ -163,7 +158,7 @@ func (p *PathPrompt) tabComplete() {
 
 func (p *PathPrompt) handleKeyPress(key *Key) {
 	p.Value, p.CursorIndex = p.TrackKeyValue(key, p.Value, p.CursorIndex)
-	if key.Name == RightKey && p.CursorIndex >= len(p.Value) {
+	if key.Name == RightKey && p.CursorIndex >= utils.GraphemeCount(p.Value) {
 		p.completeValue()
 	} else if key.Name == TabKey {
 		p.tabComplete()
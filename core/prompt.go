@@ -1,16 +1,19 @@
 package core
 
 import (
-	"bufio"
+	"context"
+	"errors"
 	"flag"
+	"fmt"
+	"io"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/Mist3rBru/go-clack/core/utils"
 	"github.com/Mist3rBru/go-clack/core/validator"
 	"github.com/Mist3rBru/go-clack/third_party/sisteransi"
-
-	"golang.org/x/term"
 )
 
 type State int
@@ -33,9 +36,18 @@ const (
 type Prompt[TValue any] struct {
 	listeners map[Event][]Listener
 
-	rl     *bufio.Reader
-	input  *os.File
-	output *os.File
+	in          *sharedInput
+	input       io.Reader
+	output      io.Writer
+	lineMode    bool
+	restoreMode func() error
+	// handlingKey is set while the prompt handles a key, and suspended while Suspend runs its function
+	handlingKey bool
+	suspended   bool
+	// mu is held while the prompt handles a key or an answer and renders it,
+	// so prompts updated from other goroutines, like AsyncSelectPrompt, can lock it to render safely.
+	// It is shared by the copies of the prompt, like the one rendered by the themes.
+	mu *sync.Mutex
 
 	State       State
 	Error       string
@@ -46,16 +58,34 @@ type Prompt[TValue any] struct {
 	ValidationDuration time.Duration
 	IsValidating       bool
 
+	// ParseAnswer updates the value from a line-based answer, when the input is not a terminal.
+	ParseAnswer func(answer string) error
+	// ParseAnswers updates the value from a list of answers, matched one by one, for prompts with a list value.
+	// Pre-supplied lists are passed as is, so their items are not split on commas like a line-based answer.
+	ParseAnswers func(answers []string) error
+	// InterceptKey is called with each pressed key before the prompt handles it.
+	// If it returns true, the prompt ignores the key, but still finishes if the interceptor set the submit or cancel state.
+	InterceptKey func(key *Key) bool
+	// IsSubmitKey reports whether a pressed key submits the prompt, which is Enter by default.
+	IsSubmitKey func(key *Key) bool
+
+	// onRun is called with the run's context once the prompt runs, which is done once the run returns.
+	onRun func(ctx context.Context)
+	// readAnswer reads a line-based answer, which is a single line by default.
+	readAnswer func(ctx context.Context) (string, error)
+
 	Render func(p *Prompt[TValue]) string
 	Frame  string
 }
 
 type PromptParams[TValue any] struct {
-	Input        *os.File
-	Output       *os.File
+	Input        io.Reader
+	Output       io.Writer
 	InitialValue TValue
 	CursorIndex  int
 	Validate     func(value TValue) error
+	ParseAnswer  func(answer string) error
+	ParseAnswers func(answers []string) error
 	Render       func(p *Prompt[TValue]) string
 }
 
@@ -75,102 +105,53 @@ func NewPrompt[TValue any](params PromptParams[TValue]) *Prompt[TValue] {
 
 		input:  params.Input,
 		output: params.Output,
-		rl:     bufio.NewReader(params.Input),
+		in:     newSharedInput(params.Input),
+		mu:     &sync.Mutex{},
 
 		State:       InitialState,
 		Value:       params.InitialValue,
 		CursorIndex: params.CursorIndex,
 
-		Validate: params.Validate,
-		Render:   params.Render,
+		Validate:     params.Validate,
+		ParseAnswer:  params.ParseAnswer,
+		ParseAnswers: params.ParseAnswers,
+		Render:       params.Render,
 	}
 }
 
-type KeyName string
-
-type Key struct {
-	Name  KeyName
-	Char  string
-	Shift bool
-	Ctrl  bool
-}
-
-const (
-	EnterKey     KeyName = "Enter"
-	SpaceKey     KeyName = "Space"
-	TabKey       KeyName = "Tab"
-	UpKey        KeyName = "Up"
-	DownKey      KeyName = "Down"
-	LeftKey      KeyName = "Left"
-	RightKey     KeyName = "Right"
-	CancelKey    KeyName = "Cancel"
-	HomeKey      KeyName = "Home"
-	EndKey       KeyName = "End"
-	BackspaceKey KeyName = "Backspace"
-)
+// PressKey handles key press events and updates the state of the prompt.
+func (p *Prompt[TValue]) PressKey(key *Key) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 
-// ParseKey parses a rune into a Key.
-func (p *Prompt[TValue]) ParseKey(r rune) *Key {
-	// TODO: parse Backtab(shift+tab) and other variations of shift and ctrl
-	switch r {
-	case '\r', '\n':
-		return &Key{Name: EnterKey}
-	case ' ':
-		return &Key{Name: SpaceKey}
-	case '\b', 127:
-		return &Key{Name: BackspaceKey}
-	case '\t':
-		return &Key{Name: TabKey}
-	case 3:
-		return &Key{Name: CancelKey}
-	case 27:
-		next, err := p.rl.Peek(2)
-		if err == nil && len(next) == 2 && next[0] == '[' {
-			switch next[1] {
-			case 'A':
-				p.rl.Discard(2)
-				return &Key{Name: UpKey}
-			case 'B':
-				p.rl.Discard(2)
-				return &Key{Name: DownKey}
-			case 'C':
-				p.rl.Discard(2)
-				return &Key{Name: RightKey}
-			case 'D':
-				p.rl.Discard(2)
-				return &Key{Name: LeftKey}
-			case 'H':
-				p.rl.Discard(2)
-				return &Key{Name: HomeKey}
-			case 'F':
-				p.rl.Discard(2)
-				return &Key{Name: EndKey}
-			}
-		}
-		return &Key{}
-	default:
-		char := string(r)
-		return &Key{Char: char, Name: KeyName(char)}
+	// Keys pressed from other goroutines while the prompt is suspended are ignored
+	if p.suspended {
+		return
 	}
-}
+	p.handlingKey = true
+	defer func() { p.handlingKey = false }()
 
-// PressKey handles key press events and updates the state of the prompt.
-func (p *Prompt[TValue]) PressKey(key *Key) {
 	if p.State == InitialState || p.State == ErrorState {
 		p.State = ActiveState
 	}
 
-	p.Emit(KeyEvent, key)
-
-	if key.Name == EnterKey {
-		if err := p.validate(); err != nil {
-			p.State = ErrorState
-			p.Error = err.Error()
+	if p.InterceptKey == nil || !p.InterceptKey(key) {
+		if key.Name == PasteKey {
+			p.Emit(PasteEvent, key.Char)
 		} else {
-			p.State = SubmitState
+			p.Emit(KeyEvent, key)
+		}
+
+		if p.isSubmitKey(key) {
+			if err := p.validate(); err != nil {
+				p.State = ErrorState
+				p.Error = err.Error()
+			} else {
+				p.State = SubmitState
+			}
+		} else if key.Name == CancelKey {
+			p.State = CancelState
 		}
-	} else if key.Name == CancelKey {
-		p.State = CancelState
 	}
 
 	if p.State == SubmitState || p.State == CancelState {
@@ -186,6 +167,13 @@ func (p *Prompt[TValue]) PressKey(key *Key) {
 	}
 }
 
+func (p *Prompt[TValue]) isSubmitKey(key *Key) bool {
+	if p.IsSubmitKey != nil {
+		return p.IsSubmitKey(key)
+	}
+	return key.Name == EnterKey
+}
+
 func (p *Prompt[TValue]) validate() error {
 	if p.Validate == nil {
 		return nil
@@ -195,17 +183,28 @@ func (p *Prompt[TValue]) validate() error {
 	p.IsValidating = true
 	p.Emit(ValidateEvent)
 
+	// The validation is rendered until it is done, which waits for the last render to finish
+	done := make(chan struct{})
+	stopped := make(chan struct{})
 	go func() {
+		defer close(stopped)
 		validationStart := time.Now()
-		time.Sleep(400 * time.Millisecond)
-		for p.IsValidating {
+		delay := 400 * time.Millisecond
+		for {
+			select {
+			case <-done:
+				return
+			case <-time.After(delay):
+			}
 			p.ValidationDuration = time.Since(validationStart)
 			p.render()
-			time.Sleep(125 * time.Millisecond)
+			delay = 125 * time.Millisecond
 		}
 	}()
 
 	err := p.Validate(p.Value)
+	close(done)
+	<-stopped
 	p.IsValidating = false
 
 	return err
@@ -230,12 +229,17 @@ func (p *Prompt[TValue]) DiffLines(oldFrame, newFrame string) []int {
 	return diff
 }
 
-func (p *Prompt[TValue]) Size() (width int, height int, err error) {
-	return term.GetSize(int(p.output.Fd()))
+// write writes a string to the output.
+func (p *Prompt[TValue]) write(str string) {
+	io.WriteString(p.output, str)
 }
 
 // render renders a new frame to the output.
 func (p *Prompt[TValue]) render() {
+	if p.lineMode || p.suspended {
+		return
+	}
+
 	frame := p.Render(p)
 
 	if lines := strings.Split(frame, "\r\n"); len(lines) == 1 {
@@ -243,8 +247,8 @@ func (p *Prompt[TValue]) render() {
 	}
 
 	if p.State == InitialState {
-		p.output.WriteString(sisteransi.HideCursor())
-		p.output.WriteString(frame)
+		p.write(sisteransi.HideCursor())
+		p.write(frame)
 		p.Frame = frame
 		return
 	}
@@ -258,35 +262,238 @@ func (p *Prompt[TValue]) render() {
 	prevFrameLines := strings.Split((p.Frame), "\n")
 
 	// Move to first diff line
-	p.output.WriteString(sisteransi.MoveCursor(-(len(prevFrameLines) - 1), -999))
-	p.output.WriteString(sisteransi.MoveCursor(diffLineIndex, 0))
-	p.output.WriteString(sisteransi.EraseDown())
+	p.write(sisteransi.MoveCursor(-(len(prevFrameLines) - 1), -999))
+	p.write(sisteransi.MoveCursor(diffLineIndex, 0))
+	p.write(sisteransi.EraseDown())
 	lines := strings.Split(frame, "\n")
 	newLines := lines[diffLineIndex:]
-	p.output.WriteString(strings.Join(newLines, "\n"))
+	p.write(strings.Join(newLines, "\n"))
 	p.Frame = frame
 }
 
+// resize erases the previous frame, as re-wrapped by the new terminal width, and renders the prompt again.
+func (p *Prompt[TValue]) resize() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.suspended {
+		return
+	}
+	width, _, err := p.Size()
+	if err != nil || width <= 0 {
+		return
+	}
+
+	rows := 0
+	for _, line := range strings.Split(p.Frame, "\n") {
+		rows += max((utils.StrLength(line)+width-1)/width, 1)
+	}
+
+	frame := p.Render(p)
+	if lines := strings.Split(frame, "\r\n"); len(lines) == 1 {
+		frame = strings.Join(strings.Split(frame, "\n"), "\r\n")
+	}
+
+	p.write(sisteransi.MoveCursor(-(rows - 1), -999))
+	p.write(sisteransi.EraseDown())
+	p.write(frame)
+	p.Frame = frame
+}
+
+// waitKey waits for the requested key, rendering the prompt again on terminal resizes.
+// It returns no key once the prompt is finished meanwhile, such as by a key pressed programmatically.
+func (p *Prompt[TValue]) waitKey(ctx context.Context, done <-chan struct{}, keys <-chan *Key, resize <-chan struct{}) (*Key, error) {
+	for {
+		select {
+		case <-done:
+			return nil, nil
+		case key, ok := <-keys:
+			if !ok {
+				return nil, io.EOF
+			}
+			return key, nil
+		case <-resize:
+			p.resize()
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// waitDone waits for the prompt to be finished, rendering it again on terminal resizes.
+func (p *Prompt[TValue]) waitDone(ctx context.Context, done <-chan struct{}, resize <-chan struct{}) error {
+	for {
+		select {
+		case <-done:
+			return nil
+		case <-resize:
+			p.resize()
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Suspend runs fn with the terminal out of raw mode and the cursor shown, such as to launch an external program,
+// then resumes the prompt's terminal mode.
+// The input is not read and the prompt is not rendered while fn runs.
+// When called while handling a key, like from a KeyEvent listener, the prompt is unlocked until fn returns,
+// so it is not blocked by a long-running program.
+func (p *Prompt[TValue]) Suspend(fn func() error) error {
+	if p.in != nil {
+		p.in.source.pause()
+		defer p.in.source.resume()
+	}
+	p.suspended = true
+	defer func() { p.suspended = false }()
+
+	p.write(sisteransi.DisableBracketedPaste())
+	p.write(sisteransi.ShowCursor())
+	if p.restoreMode != nil {
+		if err := p.restoreMode(); err != nil {
+			return err
+		}
+	}
+
+	err := p.runUnlocked(fn)
+
+	if p.restoreMode != nil {
+		restore, rawErr := p.makeRaw()
+		if rawErr != nil {
+			return errors.Join(err, rawErr)
+		}
+		p.restoreMode = restore
+	}
+	p.write(sisteransi.EnableBracketedPaste())
+	p.write(sisteransi.HideCursor())
+	return err
+}
+
+// runUnlocked runs fn, releasing the prompt's lock meanwhile if it is held to handle a key.
+func (p *Prompt[TValue]) runUnlocked(fn func() error) error {
+	if !p.handlingKey {
+		return fn()
+	}
+	p.mu.Unlock()
+	defer p.mu.Lock()
+	return fn()
+}
+
+// cancel sets the prompt to the cancel state, rendering it and notifying listeners.
+func (p *Prompt[TValue]) cancel() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.State = CancelState
+	p.Emit(FinalizeEvent)
+	p.render()
+	p.Emit(CancelEvent)
+}
+
+// readKeys reads and parses a key from the input for each request received, until stop is closed,
+// closing the keys channel once the input is exhausted or the reading is stopped.
+// A read pending when stop is closed is left to the next prompt reading the input.
+func (p *Prompt[TValue]) readKeys(requests <-chan struct{}, keys chan<- *Key, stop <-chan struct{}) {
+	defer close(keys)
+	for {
+		select {
+		case <-requests:
+		case <-stop:
+			return
+		}
+		key, ok := p.readKey(stop)
+		if !ok {
+			return
+		}
+		keys <- key
+	}
+}
+
+// readKey reads and parses the next key from the input, which is nil if the input could not be read.
+// It returns false once the input is exhausted or the reading is stopped.
+func (p *Prompt[TValue]) readKey(stop <-chan struct{}) (*Key, bool) {
+	p.in.mu.Lock()
+	defer p.in.mu.Unlock()
+
+	if !p.in.wait(stop, nil) {
+		return nil, false
+	}
+	r, size, err := p.in.ReadRune()
+	if errors.Is(err, io.EOF) {
+		return nil, false
+	}
+	if err != nil || size == 0 {
+		return nil, true
+	}
+	return p.ParseKey(r), true
+}
+
 // Run runs the prompt and processes input.
 func (p *Prompt[TValue]) Run() (TValue, error) {
+	return p.RunContext(context.Background())
+}
+
+// RunContext runs the prompt and processes input until it is submitted, canceled or the context is done.
+// When the context is done, the cancel state is rendered and the returned error wraps both ErrCancelPrompt and ctx.Err().
+// If the input is not a terminal, such as piped stdin, the prompt writes its question and reads line-based answers instead of keys,
+// unless the input is a RawModeSetter or the output a TerminalSizer.
+func (p *Prompt[TValue]) RunContext(ctx context.Context) (TValue, error) {
+	p.in = acquireInput(p.input)
+	defer releaseInput(p.in)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if p.onRun != nil {
+		p.mu.Lock()
+		p.onRun(ctx)
+		p.mu.Unlock()
+	}
+
+	if !p.isInteractive() {
+		return p.runLines(ctx)
+	}
+
 	if flag.Lookup("test.v") == nil {
-		oldState, err := term.MakeRaw(int(p.input.Fd()))
+		restore, err := p.makeRaw()
 		if err != nil {
 			return p.Value, err
 		}
-		defer term.Restore(int(p.input.Fd()), oldState)
+		// The mode is restored with the latest restore function, as Suspend makes the input raw again
+		p.restoreMode = restore
+		defer func() {
+			p.restoreMode()
+			p.restoreMode = nil
+		}()
 	}
 
 	done := make(chan struct{})
 	closeCb := func(args ...any) {
-		p.output.WriteString(sisteransi.ShowCursor())
-		p.output.WriteString("\r\n")
+		p.write(sisteransi.DisableBracketedPaste())
+		p.write(sisteransi.ShowCursor())
+		p.write("\r\n")
 		close(done)
 	}
 	p.Once(SubmitEvent, closeCb)
 	p.Once(CancelEvent, closeCb)
 
+	p.write(sisteransi.EnableBracketedPaste())
+	p.mu.Lock()
 	p.render()
+	p.mu.Unlock()
+
+	// Keys are read on demand, so no input is consumed after the prompt is finished
+	requests := make(chan struct{})
+	keys := make(chan *Key, 1)
+	stop := make(chan struct{})
+	go p.readKeys(requests, keys, stop)
+	defer func() {
+		close(stop)
+		for range keys {
+		}
+	}()
+
+	resize, stopResize := p.notifyResize()
+	defer stopResize()
 
 outer:
 	for {
@@ -294,11 +501,21 @@ outer:
 		case <-done:
 			break outer
 		default:
-			r, size, err := p.rl.ReadRune()
-			if err != nil || size == 0 || p.IsValidating {
+			requests <- struct{}{}
+			key, err := p.waitKey(ctx, done, keys, resize)
+			if errors.Is(err, io.EOF) {
+				// Once the input is exhausted, keys can only be pressed programmatically
+				if err = p.waitDone(ctx, done, resize); err == nil {
+					break outer
+				}
+			}
+			if err != nil {
+				p.cancel()
+				return p.Value, fmt.Errorf("%w: %w", ErrCancelPrompt, err)
+			}
+			if key == nil || p.IsValidating {
 				continue
 			}
-			key := p.ParseKey(r)
 			p.PressKey(key)
 		}
 	}
@@ -0,0 +1,120 @@
+package core
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/Mist3rBru/go-clack/core/utils"
+)
+
+// SetAnswer sets the value from a pre-supplied answer, without submitting it.
+// The answer is parsed like a line-based answer, unless it already has the value type.
+// A list answer is parsed item by item by prompts with a list value.
+func (p *Prompt[TValue]) SetAnswer(answer any) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.setAnswer(answer)
+}
+
+func (p *Prompt[TValue]) setAnswer(answer any) error {
+	if items, ok := answerItems(answer); ok && p.ParseAnswers != nil {
+		return p.ParseAnswers(items)
+	}
+
+	value, ok := answer.(TValue)
+	if !ok || p.ParseAnswer != nil {
+		return p.parseAnswer(formatAnswer(answer))
+	}
+
+	p.Value = value
+	if str, ok := answer.(string); ok {
+		p.CursorIndex = utils.GraphemeCount(str)
+	}
+	return nil
+}
+
+// SubmitAnswer submits a pre-supplied answer without reading any input.
+// The answer is set like in SetAnswer, then it is validated and the submitted frame is written to the output.
+// An invalid answer renders the error state and the returned error wraps ErrInvalidAnswer.
+func (p *Prompt[TValue]) SubmitAnswer(answer any) (TValue, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.lineMode = !p.isInteractive()
+
+	err := p.setAnswer(answer)
+	if err == nil {
+		err = p.validate()
+	}
+
+	if err != nil {
+		p.State = ErrorState
+		p.Error = err.Error()
+		p.printAnswerFrame()
+		return p.Value, fmt.Errorf("%w: %w", ErrInvalidAnswer, err)
+	}
+
+	p.State = SubmitState
+	p.Emit(FinalizeEvent)
+	p.printAnswerFrame()
+	p.Emit(SubmitEvent)
+	return p.Value, nil
+}
+
+// printAnswerFrame writes the rendered frame once, as plain text if the input is not a terminal.
+func (p *Prompt[TValue]) printAnswerFrame() {
+	if p.lineMode {
+		p.printFrame()
+		return
+	}
+
+	frame := p.Render(p)
+	if lines := strings.Split(frame, "\r\n"); len(lines) == 1 {
+		frame = strings.Join(strings.Split(frame, "\n"), "\r\n")
+	}
+	p.write(frame + "\r\n")
+	p.Frame = frame
+}
+
+// answerItems formats the items of a list answer, reporting whether the answer is a list.
+func answerItems(answer any) ([]string, bool) {
+	v := reflect.ValueOf(answer)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, false
+	}
+
+	items := make([]string, v.Len())
+	for i := range v.Len() {
+		items[i] = formatAnswer(v.Index(i).Interface())
+	}
+	return items, true
+}
+
+// formatAnswer formats a pre-supplied answer as a line-based answer, joining lists with commas.
+func formatAnswer(answer any) string {
+	if marshaler, ok := answer.(encoding.TextMarshaler); ok {
+		if text, err := marshaler.MarshalText(); err == nil {
+			return string(text)
+		}
+	}
+
+	v := reflect.ValueOf(answer)
+	switch v.Kind() {
+	case reflect.Invalid:
+		return ""
+	case reflect.Slice, reflect.Array:
+		items := make([]string, v.Len())
+		for i := range v.Len() {
+			items[i] = formatAnswer(v.Index(i).Interface())
+		}
+		return strings.Join(items, ",")
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	default:
+		return fmt.Sprint(answer)
+	}
+}
@@ -17,6 +17,8 @@ const (
 	CancelEvent
 	// SubmitEvent is emitted after the user submits the input, and after rendering the submit state
 	SubmitEvent
+	// PasteEvent is emitted with the pasted text when the user pastes into the terminal
+	PasteEvent
 )
 
 type Listener func(args ...any)
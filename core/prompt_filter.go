@@ -0,0 +1,103 @@
+package core
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Mist3rBru/go-clack/core/utils"
+)
+
+// MatchSearch matches the search against the text fuzzily, or as a case-insensitive regular expression if regex is set.
+// It returns the score of the match, used to rank the results, and the grapheme indexes of the matched characters.
+func MatchSearch(search, text string, regex bool) (int, []int, bool) {
+	if !regex {
+		return utils.FuzzyMatch(search, text)
+	}
+
+	searchRegex, err := regexp.Compile("(?i)" + search)
+	if err != nil {
+		return 0, nil, false
+	}
+	loc := searchRegex.FindStringIndex(text)
+	if loc == nil {
+		return 0, nil, false
+	}
+
+	var positions []int
+	offset := 0
+	for i, grapheme := range utils.Graphemes(text) {
+		if offset >= loc[0] && offset < loc[1] {
+			positions = append(positions, i)
+		}
+		offset += len(grapheme)
+	}
+	return 0, positions, true
+}
+
+// filterSearch returns the items whose text matches the search, ranked by score, then by the shortest text,
+// and sets their matched positions.
+func filterSearch[T any](items []T, search string, regex bool, text func(item T) string, setMatches func(item T, matches []int)) []T {
+	type result struct {
+		item   T
+		score  int
+		length int
+	}
+
+	var results []result
+	for _, item := range items {
+		score, matches, ok := MatchSearch(search, text(item), regex)
+		if !ok {
+			continue
+		}
+		setMatches(item, matches)
+		results = append(results, result{item: item, score: score, length: utils.GraphemeCount(text(item))})
+	}
+
+	// In regex mode, the results keep their order
+	if !regex {
+		sort.SliceStable(results, func(i, j int) bool {
+			if results[i].score != results[j].score {
+				return results[i].score > results[j].score
+			}
+			return results[i].length < results[j].length
+		})
+	}
+
+	filtered := make([]T, len(results))
+	for i, result := range results {
+		filtered[i] = result.item
+	}
+	return filtered
+}
+
+// HighlightMatches styles the text's graphemes at the matched positions, such as the positions matched by the search.
+func HighlightMatches(text string, matches []int, style func(input string) string) string {
+	if len(matches) == 0 {
+		return text
+	}
+
+	matched := make(map[int]bool, len(matches))
+	for _, i := range matches {
+		matched[i] = true
+	}
+
+	// Consecutive matches are styled together
+	var sb strings.Builder
+	var run string
+	for i, grapheme := range utils.Graphemes(text) {
+		if matched[i] {
+			run += grapheme
+			continue
+		}
+		if run != "" {
+			sb.WriteString(style(run))
+			run = ""
+		}
+		sb.WriteString(grapheme)
+	}
+	if run != "" {
+		sb.WriteString(style(run))
+	}
+	return sb.String()
+}
@@ -9,51 +9,102 @@ import (
 )
 
 // TrackKeyValue updates the string value and cursor position based on key presses.
+// The cursor position is measured in grapheme clusters, so multi-byte and combined characters are edited as a whole.
 func (p *Prompt[TValue]) TrackKeyValue(key *Key, value string, cursorIndex int) (newValue string, newCursorIndex int) {
+	graphemes := utils.Graphemes(value)
+	cursorIndex = max(min(cursorIndex, len(graphemes)), 0)
+	before := strings.Join(graphemes[:cursorIndex], "")
+	after := strings.Join(graphemes[cursorIndex:], "")
+
 	switch key.Name {
 	case BackspaceKey:
-		if cursorIndex == 0 || len(value) == 0 {
+		if cursorIndex == 0 {
 			return value, cursorIndex
 		}
-
-		cursorIndex--
-		if cursorIndex == len(value)-1 {
-			return value[0:cursorIndex], cursorIndex
+		return strings.Join(graphemes[:cursorIndex-1], "") + after, cursorIndex - 1
+	case DeleteKey:
+		if cursorIndex == len(graphemes) {
+			return value, cursorIndex
 		}
-
-		return value[0:cursorIndex] + value[cursorIndex+1:], cursorIndex
+		return before + strings.Join(graphemes[cursorIndex+1:], ""), cursorIndex
 	case HomeKey:
 		return value, 0
 	case EndKey:
-		return value, len(value)
+		return value, len(graphemes)
 	case LeftKey:
 		return value, max(cursorIndex-1, 0)
 	case RightKey:
-		return value, min(cursorIndex+1, len(value))
+		return value, min(cursorIndex+1, len(graphemes))
 	case SpaceKey:
-		return value[0:cursorIndex] + " " + value[cursorIndex:], cursorIndex + 1
+		return before + " " + after, cursorIndex + 1
 	}
 
-	if len(key.Char) == 1 {
-		return value[0:cursorIndex] + key.Char + value[cursorIndex:], cursorIndex + 1
+	if key.Char != "" {
+		// Combining characters merge with the previous cluster, so the cursor is recounted
+		before += key.Char
+		return before + after, utils.GraphemeCount(before)
 	}
 
 	return value, cursorIndex
 }
 
+// TrackPasteValue inserts pasted text at the cursor position.
+// Line breaks are normalized to "\n" if multiline, or stripped otherwise. Other control characters are removed.
+func (p *Prompt[TValue]) TrackPasteValue(text string, value string, cursorIndex int, multiline bool) (newValue string, newCursorIndex int) {
+	text = strings.NewReplacer("\r\n", "\n", "\r", "\n", "\t", " ").Replace(text)
+	text = strings.Map(func(r rune) rune {
+		if r == '\n' && multiline {
+			return r
+		}
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, text)
+
+	graphemes := utils.Graphemes(value)
+	cursorIndex = max(min(cursorIndex, len(graphemes)), 0)
+	before := strings.Join(graphemes[:cursorIndex], "") + text
+	return before + strings.Join(graphemes[cursorIndex:], ""), utils.GraphemeCount(before)
+}
+
+// ValueWithCursor highlights the grapheme cluster under the cursor, or a trailing space if the cursor is at the end of the value.
+func ValueWithCursor(value string, cursorIndex int) string {
+	graphemes := utils.Graphemes(value)
+	if cursorIndex < 0 || cursorIndex >= len(graphemes) {
+		return value + picocolors.Inverse(" ")
+	}
+	return strings.Join(graphemes[:cursorIndex], "") + picocolors.Inverse(graphemes[cursorIndex]) + strings.Join(graphemes[cursorIndex+1:], "")
+}
+
 // LimitLines limits the number of lines to fit within the terminal size.
 func (p *Prompt[TValue]) LimitLines(lines []string, usedLines int) string {
+	return p.limitLines(lines, usedLines, p.CursorIndex)
+}
+
+// ExceedsLines reports whether the lines do not fit within the terminal size, so LimitLines would cut them.
+func (p *Prompt[TValue]) ExceedsLines(lines []string, usedLines int) bool {
+	return len(lines) > p.maxLines(usedLines)
+}
+
+// maxLines returns the number of lines that fit within the terminal size, besides the used ones.
+func (p *Prompt[TValue]) maxLines(usedLines int) int {
 	_, maxRows, err := p.Size()
 	if err != nil {
 		maxRows = 10
 	}
-	maxItems := max(min(maxRows-usedLines, len(lines)), 0)
+	return maxRows - usedLines
+}
+
+// limitLines limits the number of lines to fit within the terminal size, keeping the cursor line in view.
+func (p *Prompt[TValue]) limitLines(lines []string, usedLines int, cursorIndex int) string {
+	maxItems := max(min(p.maxLines(usedLines), len(lines)), 0)
 
 	slidingWindowLocation := 0
-	if p.CursorIndex >= maxItems-3 {
-		slidingWindowLocation = max(min(p.CursorIndex-maxItems+3, len(lines)-maxItems), 0)
-	} else if p.CursorIndex < 2 {
-		slidingWindowLocation = max(p.CursorIndex-2, 0)
+	if cursorIndex >= maxItems-3 {
+		slidingWindowLocation = max(min(cursorIndex-maxItems+3, len(lines)-maxItems), 0)
+	} else if cursorIndex < 2 {
+		slidingWindowLocation = max(cursorIndex-2, 0)
 	}
 
 	result := []string{}
@@ -286,20 +337,20 @@ func (p *Prompt[TValue]) FormatLines(lines []string, options FormatLinesOptions)
 			} else if utils.StrLength(currentLine+word)+emptySlots+1 <= maxWith {
 				currentLine += " " + word
 			} else if utils.StrLength(word)+emptySlots >= maxWith {
-				var splitIndex int
+				var head, chunk string
 				if utils.StrLength(currentLine) == 0 {
-					splitIndex = maxWith - emptySlots
-					formatAndAddLine(word[0:splitIndex])
+					head, chunk = utils.SplitWidth(word, maxWith-emptySlots)
+					formatAndAddLine(head)
 				} else {
-					splitIndex = maxWith - utils.StrLength(currentLine) - emptySlots - 1
-					formatAndAddLine(currentLine + " " + word[0:splitIndex])
+					head, chunk = utils.SplitWidth(word, maxWith-utils.StrLength(currentLine)-emptySlots-1)
+					formatAndAddLine(currentLine + " " + head)
 				}
 
 				chunkLength := maxWith - emptySlots
-				chunk := word[splitIndex:]
 				for utils.StrLength(chunk) > chunkLength {
-					formatAndAddLine(chunk[0:chunkLength])
-					chunk = chunk[chunkLength:]
+					// A wide character takes two columns, so it must fit in a chunk for the split to progress
+					head, chunk = utils.SplitWidth(chunk, max(chunkLength, 2))
+					formatAndAddLine(head)
 				}
 
 				currentLine = chunk
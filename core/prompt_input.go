@@ -0,0 +1,197 @@
+package core
+
+import (
+	"bufio"
+	"io"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// sharedInput buffers an input for every prompt reading it, one prompt after another.
+// The input is read by a single long-lived goroutine, so a read still pending when a prompt finishes,
+// such as after a bare Escape key, delivers its input to the next prompt instead of consuming it.
+type sharedInput struct {
+	*bufio.Reader
+	// mu is held while a prompt reads a key or a line
+	mu     sync.Mutex
+	source *inputSource
+	users  int
+}
+
+// inputSource reads the input on request, from a goroutine started by the first request.
+type inputSource struct {
+	input    io.Reader
+	requests chan struct{}
+	chunks   chan inputChunk
+	started  bool
+	waiting  bool
+	pending  []byte
+	err      error
+
+	// readMu is held while the input is read, so pause can wait for the read to finish
+	readMu sync.Mutex
+	paused atomic.Bool
+}
+
+type inputChunk struct {
+	data []byte
+	err  error
+}
+
+// sharedInputs holds the inputs that are being read, or that have input left for the next prompt.
+var sharedInputs = struct {
+	sync.Mutex
+	inputs map[io.Reader]*sharedInput
+}{inputs: make(map[io.Reader]*sharedInput)}
+
+func newSharedInput(input io.Reader) *sharedInput {
+	source := &inputSource{
+		input:    input,
+		requests: make(chan struct{}),
+		chunks:   make(chan inputChunk, 1),
+	}
+	return &sharedInput{Reader: bufio.NewReader(source), source: source}
+}
+
+// acquireInput returns the shared reader of an input, which is released once the prompt is finished.
+func acquireInput(input io.Reader) *sharedInput {
+	if !reflect.TypeOf(input).Comparable() {
+		return newSharedInput(input)
+	}
+
+	sharedInputs.Lock()
+	defer sharedInputs.Unlock()
+
+	in, ok := sharedInputs.inputs[input]
+	if !ok {
+		in = newSharedInput(input)
+		sharedInputs.inputs[input] = in
+	}
+	in.users++
+	return in
+}
+
+// releaseInput stops reading an input once no prompt is using it,
+// unless a read is still in progress or pending, or there is input left for the next prompt.
+func releaseInput(in *sharedInput) {
+	sharedInputs.Lock()
+	defer sharedInputs.Unlock()
+
+	in.users--
+	if in.users > 0 || !in.mu.TryLock() {
+		return
+	}
+	defer in.mu.Unlock()
+	if in.source.waiting || len(in.source.pending) > 0 || in.Buffered() > 0 {
+		return
+	}
+	if sharedInputs.inputs[in.source.input] == in {
+		delete(sharedInputs.inputs, in.source.input)
+	}
+	if in.source.started {
+		close(in.source.requests)
+	}
+}
+
+// wait waits until input is available, requesting a read from the input if none is pending.
+// It returns false if done is closed or the timeout expires first, leaving the read pending.
+func (in *sharedInput) wait(done <-chan struct{}, timeout <-chan time.Time) bool {
+	if in.Buffered() > 0 {
+		return true
+	}
+	return in.source.wait(done, timeout)
+}
+
+func (s *inputSource) wait(done <-chan struct{}, timeout <-chan time.Time) bool {
+	if len(s.pending) > 0 || s.err != nil {
+		return true
+	}
+
+	if !s.started {
+		s.started = true
+		go s.run()
+	}
+	if !s.waiting {
+		s.requests <- struct{}{}
+		s.waiting = true
+	}
+
+	select {
+	case chunk := <-s.chunks:
+		s.waiting = false
+		s.pending, s.err = chunk.data, chunk.err
+		return true
+	case <-done:
+		return false
+	case <-timeout:
+		return false
+	}
+}
+
+// Read reads the pending input, waiting for the input otherwise.
+func (s *inputSource) Read(b []byte) (int, error) {
+	for len(s.pending) == 0 && s.err == nil {
+		s.wait(nil, nil)
+	}
+	if len(s.pending) > 0 {
+		n := copy(b, s.pending)
+		s.pending = s.pending[n:]
+		return n, nil
+	}
+	return 0, s.err
+}
+
+// run reads the input for each request, until the input fails, such as once it is exhausted.
+func (s *inputSource) run() {
+	for range s.requests {
+		buf := make([]byte, 4096)
+		n, err := s.read(buf)
+		s.chunks <- inputChunk{data: buf[:n], err: err}
+		if err != nil {
+			s.stop()
+			return
+		}
+	}
+}
+
+// read reads the input once it is available and not paused.
+// An input that cannot be polled, such as on Windows, is read right away, so its read cannot be paused.
+func (s *inputSource) read(b []byte) (int, error) {
+	for {
+		s.readMu.Lock()
+		if !s.paused.Load() {
+			if ready := pollInput(s.input, inputPollTimeout); ready {
+				defer s.readMu.Unlock()
+				return s.input.Read(b)
+			}
+			s.readMu.Unlock()
+			continue
+		}
+		s.readMu.Unlock()
+		time.Sleep(inputPollTimeout)
+	}
+}
+
+// pause stops reading the input until resumed, waiting for a read in progress,
+// so another program, like an editor, can read the input instead.
+func (s *inputSource) pause() {
+	s.paused.Store(true)
+	s.readMu.Lock()
+	s.readMu.Unlock()
+}
+
+func (s *inputSource) resume() {
+	s.paused.Store(false)
+}
+
+// stop removes the failed input, so a following prompt reads it again.
+func (s *inputSource) stop() {
+	sharedInputs.Lock()
+	defer sharedInputs.Unlock()
+
+	if in, ok := sharedInputs.inputs[s.input]; ok && in.source == s {
+		delete(sharedInputs.inputs, s.input)
+	}
+}
@@ -0,0 +1,33 @@
+//go:build !windows
+
+package core
+
+import (
+	"io"
+	"os"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// inputPollTimeout is how long a read waits for the input before checking whether it is paused.
+const inputPollTimeout = 50 * time.Millisecond
+
+// pollInput waits up to timeout for a file input to be readable.
+// It returns true right away for other inputs, and for files that cannot be polled.
+func pollInput(input io.Reader, timeout time.Duration) bool {
+	file, ok := input.(*os.File)
+	if !ok || file.Fd() == ^uintptr(0) {
+		return true
+	}
+
+	fds := []unix.PollFd{{Fd: int32(file.Fd()), Events: unix.POLLIN}}
+	n, err := unix.Poll(fds, int(timeout.Milliseconds()))
+	if err == unix.EINTR {
+		return false
+	}
+	if err != nil || fds[0].Revents&unix.POLLNVAL != 0 {
+		return true
+	}
+	return n > 0
+}
@@ -0,0 +1,15 @@
+//go:build windows
+
+package core
+
+import (
+	"io"
+	"time"
+)
+
+const inputPollTimeout = 50 * time.Millisecond
+
+// pollInput always returns true, since Windows console handles are not polled.
+func pollInput(input io.Reader, timeout time.Duration) bool {
+	return true
+}
@@ -0,0 +1,249 @@
+package core
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type KeyName string
+
+type Key struct {
+	Name  KeyName
+	Char  string
+	Shift bool
+	Ctrl  bool
+	// Meta is set when the key is pressed with Alt or Meta
+	Meta bool
+}
+
+const (
+	EnterKey     KeyName = "Enter"
+	SpaceKey     KeyName = "Space"
+	TabKey       KeyName = "Tab"
+	UpKey        KeyName = "Up"
+	DownKey      KeyName = "Down"
+	LeftKey      KeyName = "Left"
+	RightKey     KeyName = "Right"
+	CancelKey    KeyName = "Cancel"
+	HomeKey      KeyName = "Home"
+	EndKey       KeyName = "End"
+	BackspaceKey KeyName = "Backspace"
+	DeleteKey    KeyName = "Delete"
+	InsertKey    KeyName = "Insert"
+	PageUpKey    KeyName = "PageUp"
+	PageDownKey  KeyName = "PageDown"
+	EscapeKey    KeyName = "Escape"
+	F1Key        KeyName = "F1"
+	F2Key        KeyName = "F2"
+	F3Key        KeyName = "F3"
+	F4Key        KeyName = "F4"
+	F5Key        KeyName = "F5"
+	F6Key        KeyName = "F6"
+	F7Key        KeyName = "F7"
+	F8Key        KeyName = "F8"
+	F9Key        KeyName = "F9"
+	F10Key       KeyName = "F10"
+	F11Key       KeyName = "F11"
+	F12Key       KeyName = "F12"
+	// PasteKey holds the text of a bracketed paste in Key.Char
+	PasteKey KeyName = "Paste"
+)
+
+// escapeTimeout is how long to wait for the rest of an escape sequence before reporting a bare Escape key.
+const escapeTimeout = 50 * time.Millisecond
+
+// csiKeys maps the final byte of CSI and SS3 sequences to their keys.
+var csiKeys = map[byte]KeyName{
+	'A': UpKey,
+	'B': DownKey,
+	'C': RightKey,
+	'D': LeftKey,
+	'H': HomeKey,
+	'F': EndKey,
+	'P': F1Key,
+	'Q': F2Key,
+	'R': F3Key,
+	'S': F4Key,
+	'Z': TabKey,
+}
+
+// tildeKeys maps the first parameter of `ESC [ n ~` sequences to their keys.
+var tildeKeys = map[int]KeyName{
+	1:  HomeKey,
+	2:  InsertKey,
+	3:  DeleteKey,
+	4:  EndKey,
+	5:  PageUpKey,
+	6:  PageDownKey,
+	7:  HomeKey,
+	8:  EndKey,
+	11: F1Key,
+	12: F2Key,
+	13: F3Key,
+	14: F4Key,
+	15: F5Key,
+	17: F6Key,
+	18: F7Key,
+	19: F8Key,
+	20: F9Key,
+	21: F10Key,
+	23: F11Key,
+	24: F12Key,
+}
+
+// ParseKey parses a rune, and the escape sequence started by it, into a Key.
+func (p *Prompt[TValue]) ParseKey(r rune) *Key {
+	switch {
+	case r == '\r' || r == '\n':
+		return &Key{Name: EnterKey}
+	case r == ' ':
+		return &Key{Name: SpaceKey}
+	case r == '\b' || r == 127:
+		return &Key{Name: BackspaceKey}
+	case r == '\t':
+		return &Key{Name: TabKey}
+	case r == 3:
+		return &Key{Name: CancelKey}
+	case r == 27:
+		return p.parseEscape()
+	case r == 0:
+		return &Key{Name: SpaceKey, Ctrl: true}
+	case r >= 1 && r <= 26:
+		return &Key{Name: KeyName(rune('a' + r - 1)), Ctrl: true}
+	case r >= 28 && r <= 31:
+		return &Key{Name: KeyName(rune('\\' + r - 28)), Ctrl: true}
+	default:
+		char := string(r)
+		return &Key{Char: char, Name: KeyName(char), Shift: r >= 'A' && r <= 'Z'}
+	}
+}
+
+// parseEscape parses the input following an escape character.
+func (p *Prompt[TValue]) parseEscape() *Key {
+	if !p.hasPendingInput() {
+		return &Key{Name: EscapeKey}
+	}
+
+	next, err := p.in.ReadByte()
+	if err != nil {
+		return &Key{Name: EscapeKey}
+	}
+
+	if next == 27 {
+		key := p.parseEscape()
+		key.Meta = true
+		return key
+	}
+
+	if next == '[' || next == 'O' {
+		if p.hasPendingInput() {
+			return p.parseSequence(next)
+		}
+		key := p.ParseKey(rune(next))
+		key.Char = ""
+		key.Meta = true
+		return key
+	}
+
+	p.in.UnreadByte()
+	r, _, err := p.in.ReadRune()
+	if err != nil {
+		return &Key{Name: EscapeKey}
+	}
+	key := p.ParseKey(r)
+	key.Char = ""
+	key.Meta = true
+	return key
+}
+
+// parseSequence parses a CSI (`ESC [`) or SS3 (`ESC O`) sequence, including its modifier parameter.
+func (p *Prompt[TValue]) parseSequence(introducer byte) *Key {
+	var params []byte
+	var final byte
+	for {
+		b, err := p.in.ReadByte()
+		if err != nil {
+			return &Key{}
+		}
+		if b >= 0x40 && b <= 0x7e {
+			final = b
+			break
+		}
+		params = append(params, b)
+	}
+
+	// Linux console function keys: `ESC [ [ A` to `ESC [ [ E`
+	if introducer == '[' && final == '[' && len(params) == 0 {
+		b, err := p.in.ReadByte()
+		if err != nil || b < 'A' || b > 'E' {
+			return &Key{}
+		}
+		return &Key{Name: KeyName("F" + strconv.Itoa(int(b-'A'+1)))}
+	}
+
+	var code, modifier int
+	if fields := strings.Split(string(params), ";"); len(fields) > 0 {
+		code, _ = strconv.Atoi(fields[0])
+		if len(fields) > 1 {
+			modifier, _ = strconv.Atoi(fields[1])
+		} else if introducer == 'O' {
+			// SS3 sequences carry the modifier as their only parameter, e.g. `ESC O 5 A`
+			modifier = code
+		}
+	}
+
+	if final == '~' && code == 200 {
+		return p.parsePaste()
+	}
+
+	var key *Key
+	if final == '~' {
+		name, ok := tildeKeys[code]
+		if !ok {
+			return &Key{}
+		}
+		key = &Key{Name: name}
+	} else {
+		name, ok := csiKeys[final]
+		if !ok {
+			return &Key{}
+		}
+		key = &Key{Name: name, Shift: final == 'Z'}
+	}
+
+	// The modifier parameter is 1 plus a bitmask of Shift(1), Alt(2), Ctrl(4) and Meta(8)
+	if modifier > 1 {
+		mask := modifier - 1
+		key.Shift = key.Shift || mask&1 != 0
+		key.Meta = mask&2 != 0 || mask&8 != 0
+		key.Ctrl = mask&4 != 0
+	}
+
+	return key
+}
+
+// parsePaste reads a bracketed paste until its end sequence (`ESC [ 201 ~`).
+func (p *Prompt[TValue]) parsePaste() *Key {
+	end := []byte("\x1b[201~")
+	var paste []byte
+	for !bytes.HasSuffix(paste, end) {
+		b, err := p.in.ReadByte()
+		if err != nil {
+			return &Key{Name: PasteKey, Char: string(paste)}
+		}
+		paste = append(paste, b)
+	}
+	return &Key{Name: PasteKey, Char: string(paste[:len(paste)-len(end)])}
+}
+
+// hasPendingInput reports whether more input is available within the escape timeout.
+// If the timeout expires, the input read is left pending for the next key.
+func (p *Prompt[TValue]) hasPendingInput() bool {
+	if !p.in.wait(nil, time.After(escapeTimeout)) {
+		return false
+	}
+	_, err := p.in.Peek(1)
+	return err == nil
+}
@@ -0,0 +1,147 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Mist3rBru/go-clack/core/utils"
+)
+
+// runLines runs the prompt with line-based answers, for inputs that are not a terminal.
+// An empty answer keeps the current value, and once the input is exhausted the current value
+// is submitted if valid, otherwise the returned error wraps ErrInputExhausted.
+func (p *Prompt[TValue]) runLines(ctx context.Context) (TValue, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.lineMode = true
+	// The question is written before its answer is read
+	p.printFrame()
+
+	var answerErr error
+	for {
+		// The lock is released while waiting for the answer
+		p.mu.Unlock()
+		readAnswer := p.readLine
+		if p.readAnswer != nil {
+			readAnswer = p.readAnswer
+		}
+		line, readErr := readAnswer(ctx)
+		p.mu.Lock()
+		if err := ctx.Err(); err != nil {
+			p.State = CancelState
+			p.Emit(FinalizeEvent)
+			p.printFrame()
+			p.Emit(CancelEvent)
+			return p.Value, fmt.Errorf("%w: %w", ErrCancelPrompt, err)
+		}
+
+		answer := strings.TrimSpace(line)
+		// An invalid answer is not replaced by the current value once the input is exhausted
+		if readErr != nil && answer == "" && answerErr != nil {
+			return p.Value, fmt.Errorf("%w: %w", ErrInputExhausted, answerErr)
+		}
+
+		p.State = ActiveState
+		answerErr = p.parseAnswer(answer)
+		if answerErr == nil {
+			answerErr = p.validate()
+		}
+		if answerErr != nil {
+			p.State = ErrorState
+			p.Error = answerErr.Error()
+			p.printFrame()
+			if readErr != nil {
+				return p.Value, fmt.Errorf("%w: %w", ErrInputExhausted, answerErr)
+			}
+			continue
+		}
+
+		p.State = SubmitState
+		p.Emit(FinalizeEvent)
+		p.printFrame()
+		p.Emit(SubmitEvent)
+		return p.Value, nil
+	}
+}
+
+// readLine reads the next line from the input, until the context is done.
+// A read pending when the context is done is left to the next prompt reading the input.
+func (p *Prompt[TValue]) readLine(ctx context.Context) (string, error) {
+	p.in.mu.Lock()
+	defer p.in.mu.Unlock()
+
+	var line []byte
+	for {
+		if !p.in.wait(ctx.Done(), nil) {
+			return "", ctx.Err()
+		}
+		b, err := p.in.ReadByte()
+		if err != nil {
+			return string(line), err
+		}
+		line = append(line, b)
+		if b == '\n' {
+			return string(line), nil
+		}
+	}
+}
+
+// readLines reads the next lines from the input, until the input is exhausted
+// or a line with only the terminator, which is not part of the answer.
+func (p *Prompt[TValue]) readLines(ctx context.Context, terminator string) (string, error) {
+	var lines []string
+	for {
+		line, err := p.readLine(ctx)
+		if strings.TrimRight(line, "\r\n") == terminator {
+			return strings.Join(lines, ""), err
+		}
+		lines = append(lines, line)
+		if err != nil {
+			return strings.Join(lines, ""), err
+		}
+	}
+}
+
+// printFrame writes the rendered frame as plain text, without colors or cursor movements.
+func (p *Prompt[TValue]) printFrame() {
+	frame := utils.StripAnsi(p.Render(p))
+	frame = strings.ReplaceAll(frame, "\r\n", "\n")
+	p.write(frame + "\n")
+	p.Frame = frame
+}
+
+// parseAnswer updates the prompt value from a line-based answer.
+// Prompts without an answer parser accept the answer as is, if their value is a string.
+func (p *Prompt[TValue]) parseAnswer(answer string) error {
+	if p.ParseAnswer != nil {
+		return p.ParseAnswer(answer)
+	}
+	if answer == "" {
+		return nil
+	}
+
+	value, ok := any(answer).(TValue)
+	if !ok {
+		return fmt.Errorf("Invalid answer: %s", answer)
+	}
+	p.Value = value
+	p.CursorIndex = utils.GraphemeCount(answer)
+	return nil
+}
+
+// splitAnswer splits a comma-separated answer into its trimmed, non-empty items.
+func splitAnswer(answer string) []string {
+	var items []string
+	for _, item := range strings.Split(answer, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// matchAnswer reports whether an answer refers to an option, by its label or its value.
+func matchAnswer(answer, label string, value any) bool {
+	return strings.EqualFold(answer, label) || strings.EqualFold(answer, fmt.Sprint(value))
+}
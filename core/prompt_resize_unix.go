@@ -0,0 +1,36 @@
+//go:build !windows
+
+package core
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifyTerminalResize relays SIGWINCH signals until stopped.
+func notifyTerminalResize() (resize <-chan struct{}, stop func()) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGWINCH)
+
+	events := make(chan struct{}, 1)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-signals:
+				select {
+				case events <- struct{}{}:
+				default:
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return events, func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
@@ -0,0 +1,8 @@
+//go:build windows
+
+package core
+
+// notifyTerminalResize is a no-op, since Windows consoles do not signal resizes.
+func notifyTerminalResize() (resize <-chan struct{}, stop func()) {
+	return nil, func() {}
+}
@@ -0,0 +1,98 @@
+package core
+
+import (
+	"flag"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// TerminalSizer is an optional interface for outputs that know their own dimensions,
+// such as an SSH channel that tracks window-change requests.
+type TerminalSizer interface {
+	Size() (width int, height int, err error)
+}
+
+// RawModeSetter is an optional interface for inputs that control their own raw mode.
+// MakeRaw returns a function that restores the previous mode.
+type RawModeSetter interface {
+	MakeRaw() (restore func() error, err error)
+}
+
+// ResizeNotifier is an optional interface for outputs that report terminal resizes,
+// such as an SSH channel handling window-change requests.
+type ResizeNotifier interface {
+	ResizeEvents() <-chan struct{}
+}
+
+type fileDescriptor interface {
+	Fd() uintptr
+}
+
+// terminalFd returns the file descriptor of the stream, if it is backed by a terminal.
+func terminalFd(stream any) (int, bool) {
+	f, ok := stream.(fileDescriptor)
+	if !ok {
+		return 0, false
+	}
+	fd := int(f.Fd())
+	return fd, term.IsTerminal(fd)
+}
+
+// isInteractive reports whether the input can be read key by key.
+// Files that are not a terminal, such as piped stdin, are read line by line,
+// unless the input sets its own raw mode or the output knows its own dimensions, like custom streams.
+func (p *Prompt[TValue]) isInteractive() bool {
+	if _, ok := p.input.(RawModeSetter); ok {
+		return true
+	}
+	if _, ok := p.output.(TerminalSizer); ok {
+		return true
+	}
+	if _, ok := p.input.(fileDescriptor); !ok {
+		return true
+	}
+	// Tests press keys programmatically while the prompt runs on stdin
+	if p.input == os.Stdin && flag.Lookup("test.v") != nil {
+		return true
+	}
+	_, ok := terminalFd(p.input)
+	return ok
+}
+
+// Size returns the dimensions of the output terminal.
+func (p *Prompt[TValue]) Size() (width int, height int, err error) {
+	if sizer, ok := p.output.(TerminalSizer); ok {
+		return sizer.Size()
+	}
+	if fd, ok := terminalFd(p.output); ok {
+		return term.GetSize(fd)
+	}
+	return 0, 0, ErrNotTerminal
+}
+
+// makeRaw puts the input into raw mode, if it is a terminal.
+func (p *Prompt[TValue]) makeRaw() (restore func() error, err error) {
+	if setter, ok := p.input.(RawModeSetter); ok {
+		return setter.MakeRaw()
+	}
+	if fd, ok := terminalFd(p.input); ok {
+		oldState, err := term.MakeRaw(fd)
+		if err != nil {
+			return nil, err
+		}
+		return func() error { return term.Restore(fd, oldState) }, nil
+	}
+	return func() error { return nil }, nil
+}
+
+// notifyResize returns a channel that receives the output's resize events.
+func (p *Prompt[TValue]) notifyResize() (resize <-chan struct{}, stop func()) {
+	if notifier, ok := p.output.(ResizeNotifier); ok {
+		return notifier.ResizeEvents(), func() {}
+	}
+	if _, ok := terminalFd(p.output); ok {
+		return notifyTerminalResize()
+	}
+	return nil, func() {}
+}
@@ -1,14 +1,19 @@
 package core_test
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/Mist3rBru/go-clack/core"
 	"github.com/Mist3rBru/go-clack/third_party/picocolors"
+	"github.com/Mist3rBru/go-clack/third_party/sisteransi"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -112,6 +117,132 @@ func TestParseKey(t *testing.T) {
 	assert.Equal(t, core.Key{Name: "a", Char: "a"}, *p.ParseKey('a'))
 }
 
+func TestParseKeyVariations(t *testing.T) {
+	p := newPrompt()
+
+	assert.Equal(t, core.Key{Name: "A", Char: "A", Shift: true}, *p.ParseKey('A'))
+	assert.Equal(t, core.Key{Name: "a", Ctrl: true}, *p.ParseKey(1))
+	assert.Equal(t, core.Key{Name: "d", Ctrl: true}, *p.ParseKey(4))
+	assert.Equal(t, core.Key{Name: core.SpaceKey, Ctrl: true}, *p.ParseKey(0))
+	assert.Equal(t, core.Key{Name: "_", Ctrl: true}, *p.ParseKey(31))
+}
+
+func TestParseEscapeSequences(t *testing.T) {
+	testCases := []struct {
+		sequence string
+		expected core.Key
+	}{
+		{sequence: "", expected: core.Key{Name: core.EscapeKey}},
+		{sequence: "[A", expected: core.Key{Name: core.UpKey}},
+		{sequence: "[B", expected: core.Key{Name: core.DownKey}},
+		{sequence: "[C", expected: core.Key{Name: core.RightKey}},
+		{sequence: "[D", expected: core.Key{Name: core.LeftKey}},
+		{sequence: "[H", expected: core.Key{Name: core.HomeKey}},
+		{sequence: "[F", expected: core.Key{Name: core.EndKey}},
+		{sequence: "OA", expected: core.Key{Name: core.UpKey}},
+		{sequence: "OH", expected: core.Key{Name: core.HomeKey}},
+		{sequence: "OP", expected: core.Key{Name: core.F1Key}},
+		{sequence: "OS", expected: core.Key{Name: core.F4Key}},
+		{sequence: "[Z", expected: core.Key{Name: core.TabKey, Shift: true}},
+		{sequence: "[1~", expected: core.Key{Name: core.HomeKey}},
+		{sequence: "[2~", expected: core.Key{Name: core.InsertKey}},
+		{sequence: "[3~", expected: core.Key{Name: core.DeleteKey}},
+		{sequence: "[4~", expected: core.Key{Name: core.EndKey}},
+		{sequence: "[5~", expected: core.Key{Name: core.PageUpKey}},
+		{sequence: "[6~", expected: core.Key{Name: core.PageDownKey}},
+		{sequence: "[15~", expected: core.Key{Name: core.F5Key}},
+		{sequence: "[24~", expected: core.Key{Name: core.F12Key}},
+		{sequence: "[[A", expected: core.Key{Name: core.F1Key}},
+		{sequence: "[1;2A", expected: core.Key{Name: core.UpKey, Shift: true}},
+		{sequence: "[1;3C", expected: core.Key{Name: core.RightKey, Meta: true}},
+		{sequence: "[1;5D", expected: core.Key{Name: core.LeftKey, Ctrl: true}},
+		{sequence: "[1;6H", expected: core.Key{Name: core.HomeKey, Shift: true, Ctrl: true}},
+		{sequence: "[3;5~", expected: core.Key{Name: core.DeleteKey, Ctrl: true}},
+		{sequence: "[1;2P", expected: core.Key{Name: core.F1Key, Shift: true}},
+		{sequence: "O5B", expected: core.Key{Name: core.DownKey, Ctrl: true}},
+		{sequence: "b", expected: core.Key{Name: "b", Meta: true}},
+		{sequence: "\r", expected: core.Key{Name: core.EnterKey, Meta: true}},
+		{sequence: "\x7f", expected: core.Key{Name: core.BackspaceKey, Meta: true}},
+		{sequence: "\x1b[A", expected: core.Key{Name: core.UpKey, Meta: true}},
+		{sequence: "[", expected: core.Key{Name: "[", Meta: true}},
+		{sequence: "[99~", expected: core.Key{}},
+		{sequence: "[200~foo\nbar\x1b[A\x1b[201~", expected: core.Key{Name: core.PasteKey, Char: "foo\nbar\x1b[A"}},
+	}
+
+	for _, tC := range testCases {
+		p := core.NewPrompt(core.PromptParams[string]{
+			Input:  strings.NewReader(tC.sequence),
+			Render: func(p *core.Prompt[string]) string { return "" },
+		})
+		assert.Equal(t, tC.expected, *p.ParseKey(27), tC.sequence)
+	}
+}
+
+func TestParseBareEscapeTimeout(t *testing.T) {
+	r, w := io.Pipe()
+	defer w.Close()
+	p := core.NewPrompt(core.PromptParams[string]{
+		Input:  r,
+		Render: func(p *core.Prompt[string]) string { return "" },
+	})
+
+	assert.Equal(t, core.Key{Name: core.EscapeKey}, *p.ParseKey(27))
+}
+
+func TestRunAfterBareEscape(t *testing.T) {
+	// A pipe does not support read deadlines, like a terminal's stdin
+	r, w := io.Pipe()
+	defer w.Close()
+
+	p := core.NewPrompt(core.PromptParams[string]{
+		Input:  r,
+		Output: &bytes.Buffer{},
+		Render: func(p *core.Prompt[string]) string { return p.Value },
+	})
+	p.On(core.KeyEvent, func(args ...any) {
+		if args[0].(*core.Key).Name == core.EscapeKey {
+			p.State = core.CancelState
+		}
+	})
+
+	go w.Write([]byte("\x1b"))
+	_, err := p.Run()
+	assert.ErrorIs(t, err, core.ErrCancelPrompt)
+
+	p = core.NewPrompt(core.PromptParams[string]{
+		Input:  r,
+		Output: &bytes.Buffer{},
+		Render: func(p *core.Prompt[string]) string { return p.Value },
+	})
+	p.On(core.KeyEvent, func(args ...any) {
+		p.Value, p.CursorIndex = p.TrackKeyValue(args[0].(*core.Key), p.Value, p.CursorIndex)
+	})
+
+	go w.Write([]byte("foo\r"))
+	value, err := p.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", value)
+}
+
+func TestRunWithLineInputAcrossPrompts(t *testing.T) {
+	input := NewPipeInput("foo\nbar\n")
+	newLinePrompt := func() *core.Prompt[string] {
+		return core.NewPrompt(core.PromptParams[string]{
+			Input:  input,
+			Output: &bytes.Buffer{},
+			Render: func(p *core.Prompt[string]) string { return p.Value },
+		})
+	}
+
+	value, err := newLinePrompt().Run()
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", value)
+
+	value, err = newLinePrompt().Run()
+	assert.NoError(t, err)
+	assert.Equal(t, "bar", value)
+}
+
 func TestTrackValue(t *testing.T) {
 	p := newPrompt()
 
@@ -173,6 +304,112 @@ func TestTrackBackspace(t *testing.T) {
 	assert.Equal(t, 0, p.CursorIndex)
 }
 
+func TestTrackUnicodeValue(t *testing.T) {
+	p := newPrompt()
+
+	p.Value, p.CursorIndex = p.TrackKeyValue(&core.Key{Char: "ç"}, "", 0)
+	assert.Equal(t, "ç", p.Value)
+	assert.Equal(t, 1, p.CursorIndex)
+
+	p.Value, p.CursorIndex = p.TrackKeyValue(&core.Key{Char: "日"}, "ab", 1)
+	assert.Equal(t, "a日b", p.Value)
+	assert.Equal(t, 2, p.CursorIndex)
+
+	p.Value, p.CursorIndex = p.TrackKeyValue(&core.Key{Char: "\u0301"}, "e", 1)
+	assert.Equal(t, "e\u0301", p.Value)
+	assert.Equal(t, 1, p.CursorIndex)
+
+	p.Value, p.CursorIndex = p.TrackKeyValue(&core.Key{Name: core.BackspaceKey}, "a👍🏽b", 2)
+	assert.Equal(t, "ab", p.Value)
+	assert.Equal(t, 1, p.CursorIndex)
+
+	p.Value, p.CursorIndex = p.TrackKeyValue(&core.Key{Name: core.DeleteKey}, "日本語", 1)
+	assert.Equal(t, "日語", p.Value)
+	assert.Equal(t, 1, p.CursorIndex)
+
+	p.Value, p.CursorIndex = p.TrackKeyValue(&core.Key{Name: core.DeleteKey}, "日本語", 3)
+	assert.Equal(t, "日本語", p.Value)
+	assert.Equal(t, 3, p.CursorIndex)
+
+	p.Value, p.CursorIndex = p.TrackKeyValue(&core.Key{Name: core.EndKey}, "🇧🇷日本", 0)
+	assert.Equal(t, 3, p.CursorIndex)
+
+	p.Value, p.CursorIndex = p.TrackKeyValue(&core.Key{Name: "b", Meta: true}, "a", 1)
+	assert.Equal(t, "a", p.Value)
+	assert.Equal(t, 1, p.CursorIndex)
+}
+
+func TestValueWithCursor(t *testing.T) {
+	assert.Equal(t, "abc"+picocolors.Inverse(" "), core.ValueWithCursor("abc", 3))
+	assert.Equal(t, "a"+picocolors.Inverse("日")+"c", core.ValueWithCursor("a日c", 1))
+	assert.Equal(t, picocolors.Inverse("👍🏽")+"!", core.ValueWithCursor("👍🏽!", 0))
+}
+
+func TestTrackPasteValue(t *testing.T) {
+	p := newPrompt()
+
+	p.Value, p.CursorIndex = p.TrackPasteValue("foo\r\nbar\tbaz\x1b", "ab", 1, false)
+	assert.Equal(t, "afoobar bazb", p.Value)
+	assert.Equal(t, 11, p.CursorIndex)
+
+	p.Value, p.CursorIndex = p.TrackPasteValue("foo\r\nbar\rbaz", "", 0, true)
+	assert.Equal(t, "foo\nbar\nbaz", p.Value)
+	assert.Equal(t, 11, p.CursorIndex)
+}
+
+func TestPasteEvent(t *testing.T) {
+	p := newPrompt()
+	var pasted []any
+	p.On(core.PasteEvent, func(args ...any) {
+		pasted = args
+	})
+	p.On(core.KeyEvent, func(args ...any) {
+		assert.FailNow(t, "KeyEvent should not be emitted on paste")
+	})
+
+	p.PressKey(&core.Key{Name: core.PasteKey, Char: "foo\nbar"})
+	assert.Equal(t, []any{"foo\nbar"}, pasted)
+	assert.Equal(t, core.ActiveState, p.State)
+}
+
+func TestInterceptKey(t *testing.T) {
+	p := newPrompt()
+	var keys []core.KeyName
+	p.On(core.KeyEvent, func(args ...any) {
+		keys = append(keys, args[0].(*core.Key).Name)
+	})
+	p.InterceptKey = func(key *core.Key) bool {
+		if key.Name == core.EscapeKey {
+			p.State = core.CancelState
+			return true
+		}
+		return key.Name == core.EnterKey
+	}
+
+	p.PressKey(&core.Key{Name: core.TabKey})
+	p.PressKey(&core.Key{Name: core.EnterKey})
+	assert.Equal(t, core.ActiveState, p.State)
+
+	p.PressKey(&core.Key{Name: core.EscapeKey})
+	assert.Equal(t, core.CancelState, p.State)
+	assert.Equal(t, []core.KeyName{core.TabKey}, keys)
+}
+
+func TestRunWithBracketedPaste(t *testing.T) {
+	var output bytes.Buffer
+	p := core.NewTextPrompt(core.TextPromptParams{
+		Input:  strings.NewReader("\x1b[200~foo\rbar\x1b[201~\r"),
+		Output: &output,
+		Render: func(p *core.TextPrompt) string { return p.Value },
+	})
+
+	value, err := p.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, "foobar", value)
+	assert.True(t, strings.HasPrefix(output.String(), sisteransi.EnableBracketedPaste()))
+	assert.Contains(t, output.String(), sisteransi.DisableBracketedPaste())
+}
+
 func TestTrackState(t *testing.T) {
 	p := newPrompt()
 
@@ -583,6 +820,26 @@ func TestFormatLines(t *testing.T) {
 			},
 			expected: fmt.Sprintf("| %s |", strings.Repeat(" ", 76)),
 		},
+		{
+			description: "format wide characters",
+			lines:       []string{strings.Repeat("日", 45)},
+			options: core.FormatLinesOptions{
+				Default: core.FormatLineOptions{Start: "|"},
+			},
+			expected: strings.Join([]string{
+				fmt.Sprintf("| %s", strings.Repeat("日", 39)),
+				fmt.Sprintf("| %s", strings.Repeat("日", 6)),
+			}, "\r\n"),
+		},
+		{
+			description: "format box with wide characters",
+			lines:       []string{"日本", "ab"},
+			options: core.FormatLinesOptions{
+				Default:  core.FormatLineOptions{Sides: "|"},
+				MinWidth: 10,
+			},
+			expected: strings.Join([]string{"| 日本   |", "| ab     |"}, "\r\n"),
+		},
 		{
 			description: "format text",
 			lines:       []string{"Lorem Ipsum is simply dummy text of the printing and typesetting industry. Lorem Ipsum has been the industry's standard dummy text ever since the 1500s"},
@@ -606,3 +863,258 @@ func TestFormatLines(t *testing.T) {
 		assert.Equal(t, tC.expected, frame)
 	}
 }
+
+func TestRunWithReaderAndWriter(t *testing.T) {
+	var output bytes.Buffer
+	p := core.NewPrompt(core.PromptParams[string]{
+		Input:  strings.NewReader("foo\r"),
+		Output: &output,
+		Render: func(p *core.Prompt[string]) string { return p.Value },
+	})
+	p.On(core.KeyEvent, func(args ...any) {
+		p.Value, p.CursorIndex = p.TrackKeyValue(args[0].(*core.Key), p.Value, p.CursorIndex)
+	})
+
+	value, err := p.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", value)
+	assert.Equal(t, core.SubmitState, p.State)
+	assert.Contains(t, output.String(), "foo")
+}
+
+func TestSizeWithoutTerminal(t *testing.T) {
+	p := core.NewPrompt(core.PromptParams[string]{
+		Output: &bytes.Buffer{},
+		Render: func(p *core.Prompt[string]) string { return "" },
+	})
+
+	_, _, err := p.Size()
+	assert.ErrorIs(t, err, core.ErrNotTerminal)
+}
+
+func TestSizeWithTerminalSizer(t *testing.T) {
+	p := core.NewPrompt(core.PromptParams[string]{
+		Output: &MockTerminal{width: 20, height: 5},
+		Render: func(p *core.Prompt[string]) string { return "" },
+	})
+
+	width, height, err := p.Size()
+	assert.NoError(t, err)
+	assert.Equal(t, 20, width)
+	assert.Equal(t, 5, height)
+
+	frame := p.FormatLines([]string{strings.Repeat("a", 30)}, core.FormatLinesOptions{})
+	assert.Equal(t, strings.Join([]string{strings.Repeat("a", 20), strings.Repeat("a", 10)}, "\r\n"), frame)
+}
+
+func TestRunWithTerminalResize(t *testing.T) {
+	r, w := io.Pipe()
+	defer w.Close()
+
+	terminal := &MockTerminal{width: 40, height: 5, resize: make(chan struct{})}
+	p := core.NewPrompt(core.PromptParams[string]{
+		Input:        r,
+		Output:       terminal,
+		InitialValue: strings.Repeat("a", 30),
+		Render: func(p *core.Prompt[string]) string {
+			return p.FormatLines([]string{p.Value}, core.FormatLinesOptions{})
+		},
+	})
+
+	go func() {
+		terminal.Resize(10, 5)
+		w.Write([]byte("\r"))
+	}()
+
+	_, err := p.Run()
+	assert.NoError(t, err)
+
+	frame := strings.Join([]string{strings.Repeat("a", 10), strings.Repeat("a", 10), strings.Repeat("a", 10)}, "\r\n")
+	assert.Equal(t, frame, p.Frame)
+	assert.Contains(t, terminal.String(), sisteransi.MoveCursor(-2, -999)+sisteransi.EraseDown()+frame)
+}
+
+func TestRunContextCancellation(t *testing.T) {
+	r, w := io.Pipe()
+	defer w.Close()
+
+	p := core.NewPrompt(core.PromptParams[string]{
+		Input:        r,
+		Output:       &bytes.Buffer{},
+		InitialValue: "foo",
+		Render:       func(p *core.Prompt[string]) string { return p.Value },
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	value, err := p.RunContext(ctx)
+	assert.ErrorIs(t, err, core.ErrCancelPrompt)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, "foo", value)
+	assert.Equal(t, core.CancelState, p.State)
+
+	p = core.NewPrompt(core.PromptParams[string]{
+		Input:  r,
+		Output: &bytes.Buffer{},
+		Render: func(p *core.Prompt[string]) string { return p.Value },
+	})
+	p.On(core.KeyEvent, func(args ...any) {
+		p.Value, p.CursorIndex = p.TrackKeyValue(args[0].(*core.Key), p.Value, p.CursorIndex)
+	})
+
+	go w.Write([]byte("bar\r"))
+	value, err = p.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, "bar", value)
+}
+
+func TestRunContextCancellationWithLineInput(t *testing.T) {
+	r, w, err := os.Pipe()
+	assert.NoError(t, err)
+	defer w.Close()
+
+	newLinePrompt := func() *core.Prompt[string] {
+		return core.NewPrompt(core.PromptParams[string]{
+			Input:  r,
+			Output: &bytes.Buffer{},
+			Render: func(p *core.Prompt[string]) string { return p.Value },
+		})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = newLinePrompt().RunContext(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	w.WriteString("foo\n")
+	value, err := newLinePrompt().Run()
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", value)
+}
+
+func TestRunWithLineInput(t *testing.T) {
+	var output bytes.Buffer
+	p := core.NewPrompt(core.PromptParams[string]{
+		Input:  NewPipeInput("foo\n"),
+		Output: &output,
+		Render: func(p *core.Prompt[string]) string {
+			return picocolors.Green(p.Value) + "\r\n" + sisteransi.HideCursor()
+		},
+	})
+
+	value, err := p.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", value)
+	assert.Equal(t, core.SubmitState, p.State)
+	assert.Equal(t, "\n\nfoo\n\n", output.String())
+}
+
+func TestRunWithLineInputRetry(t *testing.T) {
+	var output bytes.Buffer
+	p := core.NewPrompt(core.PromptParams[string]{
+		Input:  NewPipeInput("foo\nbar\n"),
+		Output: &output,
+		Validate: func(value string) error {
+			if value != "bar" {
+				return errors.New("invalid value")
+			}
+			return nil
+		},
+		Render: func(p *core.Prompt[string]) string { return p.Value + " " + p.Error },
+	})
+
+	value, err := p.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, "bar", value)
+	assert.Equal(t, " \nfoo invalid value\nbar invalid value\n", output.String())
+}
+
+func TestRunWithLineInputWritesQuestionFirst(t *testing.T) {
+	input, writer, err := os.Pipe()
+	assert.NoError(t, err)
+	output := &MockOutput{}
+	p := core.NewPrompt(core.PromptParams[string]{
+		Input:  input,
+		Output: output,
+		Render: func(p *core.Prompt[string]) string { return "question? " + p.Value },
+	})
+
+	done := make(chan string, 1)
+	go func() {
+		value, _ := p.Run()
+		done <- value
+	}()
+
+	assert.Eventually(t, func() bool { return output.String() == "question? \n" }, time.Second, time.Millisecond)
+	writer.WriteString("foo\n")
+	writer.Close()
+	assert.Equal(t, "foo", <-done)
+	assert.Equal(t, "question? \nquestion? foo\n", output.String())
+}
+
+func TestRunWithPipeInputAndTerminalSizer(t *testing.T) {
+	output := &MockTerminal{width: 80, height: 24}
+	p := core.NewPrompt(core.PromptParams[string]{
+		Input:  NewPipeInput("\r"),
+		Output: output,
+		Render: func(p *core.Prompt[string]) string { return "question?" },
+	})
+
+	_, err := p.Run()
+	assert.NoError(t, err)
+	assert.Contains(t, output.String(), sisteransi.HideCursor())
+}
+
+func TestRunWithExhaustedLineInput(t *testing.T) {
+	p := core.NewPrompt(core.PromptParams[string]{
+		Input:        NewPipeInput(""),
+		Output:       &bytes.Buffer{},
+		InitialValue: "foo",
+		Render:       func(p *core.Prompt[string]) string { return p.Value },
+	})
+
+	value, err := p.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", value)
+
+	p = core.NewPrompt(core.PromptParams[string]{
+		Input:  NewPipeInput(""),
+		Output: &bytes.Buffer{},
+		Validate: func(value string) error {
+			return errors.New("value is required")
+		},
+		Render: func(p *core.Prompt[string]) string { return p.Value },
+	})
+
+	_, err = p.Run()
+	assert.ErrorIs(t, err, core.ErrInputExhausted)
+	assert.ErrorContains(t, err, "value is required")
+	assert.Equal(t, core.ErrorState, p.State)
+}
+
+func TestSubmitAnswer(t *testing.T) {
+	var output bytes.Buffer
+	p := core.NewPrompt(core.PromptParams[string]{
+		Output: &output,
+		Render: func(p *core.Prompt[string]) string { return p.Value },
+	})
+
+	value, err := p.SubmitAnswer("foo")
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", value)
+	assert.Equal(t, core.SubmitState, p.State)
+	assert.Equal(t, "foo\r\n", output.String())
+
+	p = core.NewPrompt(core.PromptParams[string]{
+		Output: &bytes.Buffer{},
+		Validate: func(value string) error {
+			return errors.New("invalid value")
+		},
+		Render: func(p *core.Prompt[string]) string { return p.Value },
+	})
+
+	_, err = p.SubmitAnswer(42)
+	assert.ErrorIs(t, err, core.ErrInvalidAnswer)
+	assert.Equal(t, "42", p.Value)
+	assert.Equal(t, core.ErrorState, p.State)
+}
@@ -1,7 +1,10 @@
 package core
 
 import (
-	"os"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
 
 	"github.com/Mist3rBru/go-clack/core/validator"
 )
@@ -18,8 +21,8 @@ type SelectKeyPrompt[TValue any] struct {
 }
 
 type SelectKeyPromptParams[TValue any] struct {
-	Input   *os.File
-	Output  *os.File
+	Input   io.Reader
+	Output  io.Writer
 	Options []*SelectKeyOption[TValue]
 	Render  func(p *SelectKeyPrompt[TValue]) string
 }
@@ -38,9 +41,10 @@ func NewSelectKeyPrompt[TValue any](params SelectKeyPromptParams[TValue]) *Selec
 	var p SelectKeyPrompt[TValue]
 	p = SelectKeyPrompt[TValue]{
 		Prompt: *NewPrompt(PromptParams[TValue]{
-			Input:  params.Input,
-			Output: params.Output,
-			Render: WrapRender[TValue](&p, params.Render),
+			Input:       params.Input,
+			Output:      params.Output,
+			ParseAnswer: p.handleAnswer,
+			Render:      WrapRender[TValue](&p, params.Render),
 		}),
 		Options: params.Options,
 	}
@@ -54,7 +58,7 @@ func NewSelectKeyPrompt[TValue any](params SelectKeyPromptParams[TValue]) *Selec
 
 func (p *SelectKeyPrompt[TValue]) handleKeyPress(key *Key) {
 	for i, option := range p.Options {
-		if key.Name == KeyName(option.Key) {
+		if key.Name == KeyName(option.Key) && !key.Ctrl && !key.Meta {
 			p.State = SubmitState
 			p.Value = option.Value
 			p.CursorIndex = i
@@ -65,3 +69,19 @@ func (p *SelectKeyPrompt[TValue]) handleKeyPress(key *Key) {
 		key.Name = ""
 	}
 }
+
+func (p *SelectKeyPrompt[TValue]) handleAnswer(answer string) error {
+	if answer == "" {
+		return errors.New("Please select an option.")
+	}
+
+	for i, option := range p.Options {
+		if answer == option.Key || strings.EqualFold(answer, option.Label) {
+			p.Value = option.Value
+			p.CursorIndex = i
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Invalid option: %s", answer)
+}
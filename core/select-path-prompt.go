@@ -1,7 +1,7 @@
 package core
 
 import (
-	"os"
+	"io"
 	"path"
 
 	"github.com/Mist3rBru/go-clack/core/internals"
@@ -17,15 +17,18 @@ type SelectPathPrompt struct {
 	OnlyShowDir   bool
 	Search        string
 	Filter        bool
-	FileSystem    FileSystem
+	// RegexFilter matches the search as a regular expression, instead of fuzzily
+	RegexFilter bool
+	FileSystem  FileSystem
 }
 
 type SelectPathPromptParams struct {
-	Input        *os.File
-	Output       *os.File
+	Input        io.Reader
+	Output       io.Writer
 	InitialValue string
 	OnlyShowDir  bool
 	Filter       bool
+	RegexFilter  bool
 	FileSystem   FileSystem
 	Render       func(p *SelectPathPrompt) string
 }
@@ -44,10 +47,12 @@ func NewSelectPathPrompt(params SelectPathPromptParams) *SelectPathPrompt {
 			Input:       params.Input,
 			Output:      params.Output,
 			CursorIndex: 1,
+			ParseAnswer: p.handleAnswer,
 			Render:      WrapRender[string](&p, params.Render),
 		}),
 		OnlyShowDir: params.OnlyShowDir,
 		Filter:      params.Filter,
+		RegexFilter: params.RegexFilter,
 		FileSystem:  params.FileSystem,
 	}
 
@@ -56,6 +61,7 @@ func NewSelectPathPrompt(params SelectPathPromptParams) *SelectPathPrompt {
 	}
 	p.Root = NewPathNode(params.InitialValue, PathNodeOptions{
 		OnlyShowDir: p.OnlyShowDir,
+		RegexFilter: p.RegexFilter,
 		FileSystem:  p.FileSystem,
 	})
 	p.CurrentLayer = p.Root.Children
@@ -97,6 +103,7 @@ func (p *SelectPathPrompt) handleKeyPress(key *Key) {
 		if p.CurrentOption.IsRoot() {
 			p.Root = NewPathNode(path.Dir(p.Root.Path), PathNodeOptions{
 				OnlyShowDir: p.OnlyShowDir,
+				RegexFilter: p.RegexFilter,
 				FileSystem:  p.FileSystem,
 			})
 			p.CurrentOption = p.Root
@@ -129,7 +136,7 @@ func (p *SelectPathPrompt) handleKeyPress(key *Key) {
 		}
 	default:
 		if p.Filter {
-			p.Search, _ = p.TrackKeyValue(key, p.Search, len(p.Search))
+			p.Search, _ = p.TrackKeyValue(key, p.Search, utils.GraphemeCount(p.Search))
 			if !p.CurrentOption.IsRoot() {
 				layerOptions := p.CurrentOption.FilteredLayer(p.Search)
 				layerIndex := p.Root.IndexOf(p.CurrentOption, layerOptions)
@@ -148,3 +155,12 @@ func (p *SelectPathPrompt) handleKeyPress(key *Key) {
 		p.Value = p.CurrentOption.Path
 	}
 }
+
+func (p *SelectPathPrompt) handleAnswer(answer string) error {
+	if answer == "" {
+		return nil
+	}
+
+	p.Value = p.Root.resolvePath(answer)
+	return nil
+}
@@ -137,15 +137,23 @@ func TestSelectPathFilter(t *testing.T) {
 
 func TestSelectPathFilterNavigate(t *testing.T) {
 	p := core.NewSelectPathPrompt(core.SelectPathPromptParams{
-		Filter: true,
-		Render: func(p *core.SelectPathPrompt) string { return "" },
+		Filter:     true,
+		FileSystem: MockFileSystem{Entries: filterEntries},
+		Render:     func(p *core.SelectPathPrompt) string { return "" },
 	})
 
+	// Prefix matches rank first, shorter names first, followed by the fuzzy match
 	p.PressKey(&core.Key{Char: "f"})
-	assert.Equal(t, 3, p.CurrentOption.Index)
+	assert.Equal(t, "file", p.CurrentOption.Name)
+
+	p.PressKey(&core.Key{Name: core.DownKey})
+	assert.Equal(t, "foo.go", p.CurrentOption.Name)
+
+	p.PressKey(&core.Key{Name: core.DownKey})
+	assert.Equal(t, "config.go", p.CurrentOption.Name)
 
 	p.PressKey(&core.Key{Name: core.DownKey})
-	assert.Equal(t, 4, p.CurrentOption.Index)
+	assert.Equal(t, "file", p.CurrentOption.Name)
 }
 
 func TestSelectPathFilterRecover(t *testing.T) {
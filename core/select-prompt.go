@@ -1,8 +1,8 @@
 package core
 
 import (
-	"os"
-	"regexp"
+	"fmt"
+	"io"
 
 	"github.com/Mist3rBru/go-clack/core/utils"
 	"github.com/Mist3rBru/go-clack/core/validator"
@@ -11,6 +11,18 @@ import (
 type SelectOption[TValue comparable] struct {
 	Label string
 	Value TValue
+	// Hint is a description of the option, left to the render to show
+	Hint string
+	// Matches are the grapheme indexes of the label matched by the search
+	Matches []int
+	// IsDisabled shows the option without letting it be selected
+	IsDisabled bool
+	// IsSeparator shows the label as a heading between the options
+	IsSeparator bool
+}
+
+func (o *SelectOption[TValue]) isSelectable() bool {
+	return !o.IsDisabled && !o.IsSeparator
 }
 
 type SelectPrompt[TValue comparable] struct {
@@ -19,15 +31,18 @@ type SelectPrompt[TValue comparable] struct {
 	Options        []*SelectOption[TValue]
 	Search         string
 	Filter         bool
-	Required       bool
+	// RegexFilter matches the search as a regular expression, instead of fuzzily
+	RegexFilter bool
+	Required    bool
 }
 
 type SelectPromptParams[TValue comparable] struct {
-	Input        *os.File
-	Output       *os.File
+	Input        io.Reader
+	Output       io.Writer
 	InitialValue TValue
 	Options      []*SelectOption[TValue]
 	Filter       bool
+	RegexFilter  bool
 	Required     bool
 	Render       func(p *SelectPrompt[TValue]) string
 }
@@ -37,29 +52,40 @@ func NewSelectPrompt[TValue comparable](params SelectPromptParams[TValue]) *Sele
 	v.ValidateRender(params.Render)
 	v.ValidateOptions(len(params.Options))
 
-	startIndex := 0
+	startIndex := -1
 	for i, option := range params.Options {
-		if value, ok := any(option.Value).(string); ok && value == "" {
+		if value, ok := any(option.Value).(string); ok && value == "" && !option.IsSeparator {
 			option.Value = any(option.Label).(TValue)
 		}
-		if option.Value == params.InitialValue {
+		if option.Value == params.InitialValue && option.isSelectable() {
 			startIndex = i
 		}
 	}
+	if startIndex == -1 {
+		startIndex = nextSelectableIndex(-1, 1, len(params.Options), func(i int) bool {
+			return params.Options[i].isSelectable()
+		})
+	}
+	var initialValue TValue
+	if startIndex != -1 {
+		initialValue = params.Options[startIndex].Value
+	}
 
 	var p SelectPrompt[TValue]
 	p = SelectPrompt[TValue]{
 		Prompt: *NewPrompt(PromptParams[TValue]{
 			Input:        params.Input,
 			Output:       params.Output,
-			InitialValue: params.Options[startIndex].Value,
+			InitialValue: initialValue,
 			CursorIndex:  startIndex,
 			Validate:     WrapValidate[TValue](nil, &p.Required, "Please select an option."),
+			ParseAnswer:  p.handleAnswer,
 			Render:       WrapRender[TValue](&p, params.Render),
 		}),
 		initialOptions: params.Options,
 		Options:        params.Options,
 		Filter:         params.Filter,
+		RegexFilter:    params.RegexFilter,
 		Required:       params.Required,
 	}
 
@@ -73,13 +99,13 @@ func NewSelectPrompt[TValue comparable](params SelectPromptParams[TValue]) *Sele
 func (p *SelectPrompt[TValue]) handleKeyPress(key *Key) {
 	switch key.Name {
 	case UpKey, LeftKey:
-		p.CursorIndex = utils.MinMaxIndex(p.CursorIndex-1, len(p.Options))
+		p.CursorIndex = nextSelectableIndex(p.CursorIndex, -1, len(p.Options), p.isSelectable)
 	case DownKey, RightKey:
-		p.CursorIndex = utils.MinMaxIndex(p.CursorIndex+1, len(p.Options))
+		p.CursorIndex = nextSelectableIndex(p.CursorIndex, 1, len(p.Options), p.isSelectable)
 	case HomeKey:
-		p.CursorIndex = 0
+		p.CursorIndex = nextSelectableIndex(-1, 1, len(p.Options), p.isSelectable)
 	case EndKey:
-		p.CursorIndex = len(p.Options) - 1
+		p.CursorIndex = nextSelectableIndex(len(p.Options), -1, len(p.Options), p.isSelectable)
 	case EnterKey, CancelKey:
 	default:
 		if p.Filter {
@@ -87,7 +113,7 @@ func (p *SelectPrompt[TValue]) handleKeyPress(key *Key) {
 		}
 	}
 
-	if p.CursorIndex >= 0 && p.CursorIndex < len(p.Options) {
+	if p.CursorIndex >= 0 && p.CursorIndex < len(p.Options) && p.isSelectable(p.CursorIndex) {
 		p.Value = p.Options[p.CursorIndex].Value
 		return
 	}
@@ -95,14 +121,41 @@ func (p *SelectPrompt[TValue]) handleKeyPress(key *Key) {
 	p.Value = *new(TValue)
 }
 
+func (p *SelectPrompt[TValue]) isSelectable(index int) bool {
+	return p.Options[index].isSelectable()
+}
+
+func (p *SelectPrompt[TValue]) handleAnswer(answer string) error {
+	if answer == "" {
+		return nil
+	}
+
+	p.Search = ""
+	p.Options = p.initialOptions
+	for i, option := range p.Options {
+		option.Matches = nil
+		if option.isSelectable() && matchAnswer(answer, option.Label, option.Value) {
+			p.CursorIndex = i
+			p.Value = option.Value
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Invalid option: %s", answer)
+}
+
 func (p *SelectPrompt[TValue]) filterOptions(key *Key) {
-	p.Search, _ = p.TrackKeyValue(key, p.Search, len(p.Search))
+	p.Search, _ = p.TrackKeyValue(key, p.Search, utils.GraphemeCount(p.Search))
 	p.CursorIndex = 0
+	for _, option := range p.initialOptions {
+		option.Matches = nil
+	}
 
 	if p.Search == "" {
 		p.Options = p.initialOptions
+		p.CursorIndex = nextSelectableIndex(-1, 1, len(p.Options), p.isSelectable)
 		for i, option := range p.Options {
-			if option.Value == p.Value {
+			if option.Value == p.Value && option.isSelectable() {
 				p.CursorIndex = i
 				break
 			}
@@ -110,18 +163,33 @@ func (p *SelectPrompt[TValue]) filterOptions(key *Key) {
 		return
 	}
 
-	p.Options = []*SelectOption[TValue]{}
-	searchRegex, err := regexp.Compile("(?i)" + p.Search)
-	if err != nil {
-		return
+	// Only the selectable options are filtered
+	var options []*SelectOption[TValue]
+	for _, option := range p.initialOptions {
+		if option.isSelectable() {
+			options = append(options, option)
+		}
 	}
+	p.Options = filterSearch(options, p.Search, p.RegexFilter,
+		func(option *SelectOption[TValue]) string { return option.Label },
+		func(option *SelectOption[TValue], matches []int) { option.Matches = matches },
+	)
+	for i, option := range p.Options {
+		if option.Value == p.Value {
+			p.CursorIndex = i
+			break
+		}
+	}
+}
 
-	for _, option := range p.initialOptions {
-		if matched := searchRegex.MatchString(option.Label); matched {
-			p.Options = append(p.Options, option)
-			if option.Value == p.Value {
-				p.CursorIndex = len(p.Options) - 1
-			}
+// nextSelectableIndex returns the index of the next selectable option from the index in the step's direction,
+// wrapping around the options, or -1 if none of them is selectable.
+func nextSelectableIndex(index, step, length int, isSelectable func(index int) bool) int {
+	for range length {
+		index = utils.MinMaxIndex(index+step, length)
+		if isSelectable(index) {
+			return index
 		}
 	}
+	return -1
 }
@@ -1,6 +1,7 @@
 package core_test
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/Mist3rBru/go-clack/core"
@@ -152,3 +153,173 @@ func TestSelectFilterOutOptions(t *testing.T) {
 	p.PressKey(&core.Key{Name: core.BackspaceKey})
 	assert.Equal(t, 2, p.CursorIndex)
 }
+
+func TestSelectLineAnswer(t *testing.T) {
+	p := core.NewSelectPrompt(core.SelectPromptParams[int]{
+		Input:  NewPipeInput("qux\nBar\n"),
+		Output: &bytes.Buffer{},
+		Options: []*core.SelectOption[int]{
+			{Label: "foo", Value: 1},
+			{Label: "bar", Value: 2},
+		},
+		Render: func(p *core.SelectPrompt[int]) string { return "" },
+	})
+
+	value, err := p.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, value)
+	assert.Equal(t, 1, p.CursorIndex)
+
+	p.ParseAnswer("1")
+	assert.Equal(t, 1, p.Value)
+}
+
+func TestSelectFuzzyFilter(t *testing.T) {
+	p := core.NewSelectPrompt(core.SelectPromptParams[string]{
+		Options: []*core.SelectOption[string]{
+			{Label: "bar-code"},
+			{Label: "my-barcode"},
+			{Label: "c++"},
+			{Label: "code"},
+		},
+		Filter: true,
+		Render: func(p *core.SelectPrompt[string]) string { return "" },
+	})
+
+	for _, char := range "code" {
+		p.PressKey(&core.Key{Char: string(char)})
+	}
+	assert.Equal(t, []string{"code", "bar-code", "my-barcode"}, selectLabels(p.Options))
+	assert.Equal(t, []int{0, 1, 2, 3}, p.Options[0].Matches)
+	assert.Equal(t, []int{4, 5, 6, 7}, p.Options[1].Matches)
+	// The selected option keeps the cursor while it is still listed
+	assert.Equal(t, 1, p.CursorIndex)
+	assert.Equal(t, "bar-code", p.Value)
+
+	p.Search = ""
+	p.PressKey(&core.Key{Char: "+"})
+	assert.Equal(t, []string{"c++"}, selectLabels(p.Options))
+
+	p.PressKey(&core.Key{Name: core.BackspaceKey})
+	assert.Nil(t, p.Options[0].Matches)
+}
+
+func TestSelectRegexFilter(t *testing.T) {
+	p := newSelectPrompt()
+	p.Filter = true
+	p.RegexFilter = true
+
+	p.PressKey(&core.Key{Char: "^"})
+	p.PressKey(&core.Key{Char: "b"})
+	p.PressKey(&core.Key{Char: "."})
+	p.PressKey(&core.Key{Char: "r"})
+	assert.Equal(t, []string{"bar"}, selectLabels(p.Options))
+	assert.Equal(t, []int{0, 1, 2}, p.Options[0].Matches)
+
+	p.PressKey(&core.Key{Char: "("})
+	assert.Empty(t, p.Options)
+}
+
+func selectLabels[TValue comparable](options []*core.SelectOption[TValue]) []string {
+	labels := make([]string, len(options))
+	for i, option := range options {
+		labels[i] = option.Label
+	}
+	return labels
+}
+
+func newDisabledSelectPrompt() *core.SelectPrompt[string] {
+	return core.NewSelectPrompt(core.SelectPromptParams[string]{
+		Options: []*core.SelectOption[string]{
+			{Label: "Databases", IsSeparator: true},
+			{Label: "postgres", IsDisabled: true},
+			{Label: "mysql"},
+			{Label: "sqlite"},
+			{Label: "Caches", IsSeparator: true},
+			{Label: "redis"},
+			{Label: "memcached", IsDisabled: true},
+		},
+		Filter: true,
+		Render: func(p *core.SelectPrompt[string]) string { return "" },
+	})
+}
+
+func TestSelectDisabledOptionsCursor(t *testing.T) {
+	p := newDisabledSelectPrompt()
+
+	assert.Equal(t, 2, p.CursorIndex)
+	assert.Equal(t, "mysql", p.Value)
+	p.PressKey(&core.Key{Name: core.UpKey})
+	assert.Equal(t, 5, p.CursorIndex)
+	assert.Equal(t, "redis", p.Value)
+	p.PressKey(&core.Key{Name: core.DownKey})
+	assert.Equal(t, 2, p.CursorIndex)
+	p.PressKey(&core.Key{Name: core.DownKey})
+	p.PressKey(&core.Key{Name: core.DownKey})
+	assert.Equal(t, 5, p.CursorIndex)
+
+	p.PressKey(&core.Key{Name: core.HomeKey})
+	assert.Equal(t, 2, p.CursorIndex)
+	p.PressKey(&core.Key{Name: core.EndKey})
+	assert.Equal(t, 5, p.CursorIndex)
+}
+
+func TestSelectDisabledInitialValue(t *testing.T) {
+	p := core.NewSelectPrompt(core.SelectPromptParams[string]{
+		Options: []*core.SelectOption[string]{
+			{Label: "foo"},
+			{Label: "bar", IsDisabled: true},
+		},
+		InitialValue: "bar",
+		Render:       func(p *core.SelectPrompt[string]) string { return "" },
+	})
+
+	assert.Equal(t, 0, p.CursorIndex)
+	assert.Equal(t, "foo", p.Value)
+}
+
+func TestSelectFilterDisabledOptions(t *testing.T) {
+	p := newDisabledSelectPrompt()
+
+	p.PressKey(&core.Key{Char: "r"})
+	p.PressKey(&core.Key{Char: "e"})
+	assert.Equal(t, []string{"redis"}, selectLabels(p.Options))
+	assert.Equal(t, "redis", p.Value)
+
+	p.PressKey(&core.Key{Name: core.BackspaceKey})
+	p.PressKey(&core.Key{Name: core.BackspaceKey})
+	assert.Equal(t, 7, len(p.Options))
+	assert.Equal(t, 5, p.CursorIndex)
+}
+
+func TestSelectRequiredDisabledOptions(t *testing.T) {
+	p := core.NewSelectPrompt(core.SelectPromptParams[string]{
+		Options: []*core.SelectOption[string]{
+			{Label: "Databases", IsSeparator: true},
+			{Label: "postgres", IsDisabled: true},
+		},
+		Required: true,
+		Render:   func(p *core.SelectPrompt[string]) string { return "" },
+	})
+
+	assert.Equal(t, -1, p.CursorIndex)
+	p.PressKey(&core.Key{Name: core.DownKey})
+	assert.Equal(t, -1, p.CursorIndex)
+	p.PressKey(&core.Key{Name: core.EnterKey})
+	assert.Equal(t, core.ErrorState, p.State)
+}
+
+func TestSelectDisabledLineAnswer(t *testing.T) {
+	p := core.NewSelectPrompt(core.SelectPromptParams[string]{
+		Input:  NewPipeInput("bar\n"),
+		Output: &bytes.Buffer{},
+		Options: []*core.SelectOption[string]{
+			{Label: "foo"},
+			{Label: "bar", IsDisabled: true},
+		},
+		Render: func(p *core.SelectPrompt[string]) string { return "" },
+	})
+
+	_, err := p.Run()
+	assert.ErrorContains(t, err, "Invalid option: bar")
+}
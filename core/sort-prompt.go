@@ -0,0 +1,154 @@
+package core
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Mist3rBru/go-clack/core/utils"
+	"github.com/Mist3rBru/go-clack/core/validator"
+)
+
+type SortOption[TValue any] struct {
+	Label string
+	Value TValue
+}
+
+type SortPrompt[TValue any] struct {
+	Prompt[[]TValue]
+	Options []*SortOption[TValue]
+	// IsGrabbed is whether the option under the cursor is moved along with it
+	IsGrabbed bool
+}
+
+type SortPromptParams[TValue any] struct {
+	Input    io.Reader
+	Output   io.Writer
+	Options  []*SortOption[TValue]
+	Validate func(value []TValue) error
+	Render   func(p *SortPrompt[TValue]) string
+}
+
+// NewSortPrompt creates a prompt to reorder the options, whose value is the options' values in order.
+// Space grabs the option under the cursor, Up and Down move it, and Space drops it again.
+func NewSortPrompt[TValue any](params SortPromptParams[TValue]) *SortPrompt[TValue] {
+	v := validator.NewValidator("SortPrompt")
+	v.ValidateRender(params.Render)
+	v.ValidateOptions(len(params.Options))
+
+	for _, option := range params.Options {
+		if value, ok := any(option.Value).(string); ok && value == "" {
+			option.Value = any(option.Label).(TValue)
+		}
+	}
+
+	var p SortPrompt[TValue]
+	p = SortPrompt[TValue]{
+		Prompt: *NewPrompt(PromptParams[[]TValue]{
+			Input:        params.Input,
+			Output:       params.Output,
+			InitialValue: mapSortValue(params.Options),
+			Validate:     params.Validate,
+			ParseAnswer:  p.handleAnswer,
+			ParseAnswers: p.handleAnswers,
+			Render:       WrapRender[[]TValue](&p, params.Render),
+		}),
+		Options: params.Options,
+	}
+
+	p.On(KeyEvent, func(args ...any) {
+		p.handleKeyPress(args[0].(*Key))
+	})
+
+	return &p
+}
+
+func (p *SortPrompt[TValue]) handleKeyPress(key *Key) {
+	switch key.Name {
+	case UpKey, LeftKey:
+		if p.IsGrabbed {
+			p.moveOption(p.CursorIndex - 1)
+			return
+		}
+		p.CursorIndex = utils.MinMaxIndex(p.CursorIndex-1, len(p.Options))
+	case DownKey, RightKey:
+		if p.IsGrabbed {
+			p.moveOption(p.CursorIndex + 1)
+			return
+		}
+		p.CursorIndex = utils.MinMaxIndex(p.CursorIndex+1, len(p.Options))
+	case HomeKey:
+		if p.IsGrabbed {
+			p.moveOption(0)
+			return
+		}
+		p.CursorIndex = 0
+	case EndKey:
+		if p.IsGrabbed {
+			p.moveOption(len(p.Options) - 1)
+			return
+		}
+		p.CursorIndex = len(p.Options) - 1
+	case SpaceKey:
+		p.IsGrabbed = !p.IsGrabbed
+	case EnterKey:
+		p.IsGrabbed = false
+	}
+}
+
+// moveOption moves the grabbed option to the index, within the options, shifting the ones in between.
+func (p *SortPrompt[TValue]) moveOption(index int) {
+	index = max(min(index, len(p.Options)-1), 0)
+	option := p.Options[p.CursorIndex]
+	if index < p.CursorIndex {
+		copy(p.Options[index+1:p.CursorIndex+1], p.Options[index:p.CursorIndex])
+	} else {
+		copy(p.Options[p.CursorIndex:index], p.Options[p.CursorIndex+1:index+1])
+	}
+	p.Options[index] = option
+	p.CursorIndex = index
+	p.Value = mapSortValue(p.Options)
+}
+
+func (p *SortPrompt[TValue]) handleAnswer(answer string) error {
+	if answer == "" {
+		return nil
+	}
+	return p.handleAnswers(splitAnswer(answer))
+}
+
+// handleAnswers moves the answered options to the top, in the answered order, followed by the remaining options.
+func (p *SortPrompt[TValue]) handleAnswers(answers []string) error {
+	var options []*SortOption[TValue]
+	isSorted := make(map[*SortOption[TValue]]bool)
+	for _, item := range answers {
+		found := false
+		for _, option := range p.Options {
+			if !isSorted[option] && matchAnswer(item, option.Label, option.Value) {
+				options = append(options, option)
+				isSorted[option] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("Invalid option: %s", item)
+		}
+	}
+	for _, option := range p.Options {
+		if !isSorted[option] {
+			options = append(options, option)
+		}
+	}
+
+	p.Options = options
+	p.Value = mapSortValue(p.Options)
+	return nil
+}
+
+func mapSortValue[TValue any](options []*SortOption[TValue]) []TValue {
+	value := make([]TValue, len(options))
+	for i, option := range options {
+		value[i] = option.Value
+	}
+	return value
+}
@@ -0,0 +1,118 @@
+package core_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Mist3rBru/go-clack/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSortPrompt() *core.SortPrompt[string] {
+	return core.NewSortPrompt(core.SortPromptParams[string]{
+		Options: []*core.SortOption[string]{
+			{Label: "foo"},
+			{Label: "bar"},
+			{Label: "baz"},
+		},
+		Render: func(p *core.SortPrompt[string]) string { return "" },
+	})
+}
+
+func TestSortInitialValue(t *testing.T) {
+	p := newSortPrompt()
+
+	assert.Equal(t, []string{"foo", "bar", "baz"}, p.Value)
+	assert.Equal(t, 0, p.CursorIndex)
+	assert.False(t, p.IsGrabbed)
+}
+
+func TestChangeSortCursor(t *testing.T) {
+	p := newSortPrompt()
+
+	p.PressKey(&core.Key{Name: core.DownKey})
+	assert.Equal(t, 1, p.CursorIndex)
+	p.PressKey(&core.Key{Name: core.EndKey})
+	assert.Equal(t, 2, p.CursorIndex)
+	p.PressKey(&core.Key{Name: core.DownKey})
+	assert.Equal(t, 0, p.CursorIndex)
+	p.PressKey(&core.Key{Name: core.UpKey})
+	assert.Equal(t, 2, p.CursorIndex)
+	p.PressKey(&core.Key{Name: core.HomeKey})
+	assert.Equal(t, 0, p.CursorIndex)
+
+	assert.Equal(t, []string{"foo", "bar", "baz"}, p.Value)
+}
+
+func TestSortMoveOption(t *testing.T) {
+	p := newSortPrompt()
+
+	p.PressKey(&core.Key{Name: core.SpaceKey})
+	assert.True(t, p.IsGrabbed)
+
+	p.PressKey(&core.Key{Name: core.DownKey})
+	assert.Equal(t, []string{"bar", "foo", "baz"}, p.Value)
+	assert.Equal(t, 1, p.CursorIndex)
+
+	p.PressKey(&core.Key{Name: core.DownKey})
+	p.PressKey(&core.Key{Name: core.DownKey})
+	assert.Equal(t, []string{"bar", "baz", "foo"}, p.Value)
+	assert.Equal(t, 2, p.CursorIndex)
+
+	p.PressKey(&core.Key{Name: core.HomeKey})
+	assert.Equal(t, []string{"foo", "bar", "baz"}, p.Value)
+	p.PressKey(&core.Key{Name: core.EndKey})
+	assert.Equal(t, []string{"bar", "baz", "foo"}, p.Value)
+
+	p.PressKey(&core.Key{Name: core.SpaceKey})
+	assert.False(t, p.IsGrabbed)
+	p.PressKey(&core.Key{Name: core.UpKey})
+	assert.Equal(t, 1, p.CursorIndex)
+	assert.Equal(t, []string{"bar", "baz", "foo"}, p.Value)
+	assert.Equal(t, "baz", p.Options[1].Label)
+}
+
+func TestSortSubmit(t *testing.T) {
+	p := newSortPrompt()
+
+	p.PressKey(&core.Key{Name: core.EndKey})
+	p.PressKey(&core.Key{Name: core.SpaceKey})
+	p.PressKey(&core.Key{Name: core.UpKey})
+	p.PressKey(&core.Key{Name: core.EnterKey})
+
+	assert.Equal(t, core.SubmitState, p.State)
+	assert.False(t, p.IsGrabbed)
+	assert.Equal(t, []string{"foo", "baz", "bar"}, p.Value)
+}
+
+func TestSortLineAnswer(t *testing.T) {
+	p := core.NewSortPrompt(core.SortPromptParams[string]{
+		Input:  NewPipeInput("baz, foo\n"),
+		Output: &bytes.Buffer{},
+		Options: []*core.SortOption[string]{
+			{Label: "foo"},
+			{Label: "bar"},
+			{Label: "baz"},
+		},
+		Render: func(p *core.SortPrompt[string]) string { return "" },
+	})
+
+	value, err := p.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"baz", "foo", "bar"}, value)
+}
+
+func TestSortPromptSubmitListAnswer(t *testing.T) {
+	p := core.NewSortPrompt(core.SortPromptParams[string]{
+		Output: &bytes.Buffer{},
+		Options: []*core.SortOption[string]{
+			{Label: "foo"},
+			{Label: "bar, baz"},
+		},
+		Render: func(p *core.SortPrompt[string]) string { return "" },
+	})
+
+	value, err := p.SubmitAnswer([]any{"bar, baz"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"bar, baz", "foo"}, value)
+}
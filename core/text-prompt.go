@@ -1,10 +1,10 @@
 package core
 
 import (
-	"os"
+	"io"
 
+	"github.com/Mist3rBru/go-clack/core/utils"
 	"github.com/Mist3rBru/go-clack/core/validator"
-	"github.com/Mist3rBru/go-clack/third_party/picocolors"
 )
 
 type TextPrompt struct {
@@ -14,8 +14,8 @@ type TextPrompt struct {
 }
 
 type TextPromptParams struct {
-	Input        *os.File
-	Output       *os.File
+	Input        io.Reader
+	Output       io.Writer
 	InitialValue string
 	Placeholder  string
 	Required     bool
@@ -33,7 +33,7 @@ func NewTextPrompt(params TextPromptParams) *TextPrompt {
 			Input:        params.Input,
 			Output:       params.Output,
 			InitialValue: params.InitialValue,
-			CursorIndex:  len(params.InitialValue),
+			CursorIndex:  utils.GraphemeCount(params.InitialValue),
 			Validate:     WrapValidate(params.Validate, &p.Required, "Value is required! Please enter a value."),
 			Render:       WrapRender[string](&p, params.Render),
 		}),
@@ -45,13 +45,17 @@ func NewTextPrompt(params TextPromptParams) *TextPrompt {
 		p.handleKeyPress(args[0].(*Key))
 	})
 
+	p.On(PasteEvent, func(args ...any) {
+		p.Value, p.CursorIndex = p.TrackPasteValue(args[0].(string), p.Value, p.CursorIndex, false)
+	})
+
 	return &p
 }
 
 func (p *TextPrompt) handleKeyPress(key *Key) {
 	if key.Name == TabKey && p.Value == "" && p.Placeholder != "" {
 		p.Value = p.Placeholder
-		p.CursorIndex = len(p.Placeholder)
+		p.CursorIndex = utils.GraphemeCount(p.Placeholder)
 		return
 	}
 
@@ -59,8 +63,5 @@ func (p *TextPrompt) handleKeyPress(key *Key) {
 }
 
 func (p *TextPrompt) ValueWithCursor() string {
-	if p.CursorIndex == len(p.Value) {
-		return p.Value + picocolors.Inverse(" ")
-	}
-	return p.Value[0:p.CursorIndex] + picocolors.Inverse(string(p.Value[p.CursorIndex])) + p.Value[p.CursorIndex+1:]
+	return ValueWithCursor(p.Value, p.CursorIndex)
 }
@@ -42,6 +42,25 @@ func TestTextPromptValueTrack(t *testing.T) {
 	assert.Equal(t, "b", p.Value)
 }
 
+func TestTextPromptUnicodeValueTrack(t *testing.T) {
+	p := core.NewTextPrompt(core.TextPromptParams{
+		InitialValue: "日本",
+		Render:       func(p *core.TextPrompt) string { return "" },
+	})
+	assert.Equal(t, 2, p.CursorIndex)
+
+	p.PressKey(&core.Key{Char: "👍"})
+	p.PressKey(&core.Key{Char: "🏽"})
+	assert.Equal(t, "日本👍🏽", p.Value)
+	assert.Equal(t, 3, p.CursorIndex)
+
+	p.PressKey(&core.Key{Name: core.LeftKey})
+	assert.Equal(t, "日本"+picocolors.Inverse("👍🏽"), p.ValueWithCursor())
+
+	p.PressKey(&core.Key{Name: core.BackspaceKey})
+	assert.Equal(t, "日👍🏽", p.Value)
+}
+
 func TestTextPromptValueWithCursor(t *testing.T) {
 	p := newTextPrompt()
 	cursor := picocolors.Inverse(" ")
@@ -0,0 +1,114 @@
+package utils
+
+import (
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	scoreMatch        = 16
+	scoreGapStart     = -3
+	scoreGapExtension = -1
+	bonusBoundary     = 8
+	bonusCamelCase    = 7
+	bonusConsecutive  = 6
+	bonusFirstChar    = 2
+
+	noMatch = math.MinInt32
+)
+
+// FuzzyMatch matches the pattern's characters in order within the text, ignoring case, like fzf.
+// It returns the score of the best match, which is higher for consecutive characters and word starts,
+// and the grapheme indexes of the matched characters, or false if the text does not contain the pattern.
+func FuzzyMatch(pattern, text string) (int, []int, bool) {
+	patternChars := Graphemes(strings.ToLower(pattern))
+	textChars := Graphemes(text)
+	if len(patternChars) == 0 {
+		return 0, nil, true
+	}
+	if len(patternChars) > len(textChars) {
+		return 0, nil, false
+	}
+
+	lowerChars := make([]string, len(textChars))
+	bonuses := make([]int, len(textChars))
+	for i, char := range textChars {
+		lowerChars[i] = strings.ToLower(char)
+		bonuses[i] = charBonus(textChars, i)
+	}
+
+	// scores[i][j] is the best score of the pattern up to i, with its i-th character matched at the text's j-th one,
+	// and prev[i][j] is where the previous character was matched
+	scores := make([][]int, len(patternChars))
+	prev := make([][]int, len(patternChars))
+	for i, patternChar := range patternChars {
+		scores[i] = make([]int, len(textChars))
+		prev[i] = make([]int, len(textChars))
+		for j := range textChars {
+			scores[i][j] = noMatch
+			if lowerChars[j] != patternChar {
+				continue
+			}
+
+			if i == 0 {
+				scores[i][j] = scoreMatch + bonuses[j]*bonusFirstChar
+				continue
+			}
+
+			for k := i - 1; k < j; k++ {
+				if scores[i-1][k] == noMatch {
+					continue
+				}
+
+				score := scores[i-1][k] + scoreMatch + bonuses[j]
+				if gap := j - k - 1; gap == 0 {
+					score += bonusConsecutive
+				} else {
+					score += scoreGapStart + (gap-1)*scoreGapExtension
+				}
+				if score > scores[i][j] {
+					scores[i][j] = score
+					prev[i][j] = k
+				}
+			}
+		}
+	}
+
+	last := len(patternChars) - 1
+	end := -1
+	for j, score := range scores[last] {
+		if score != noMatch && (end < 0 || score > scores[last][end]) {
+			end = j
+		}
+	}
+	if end < 0 {
+		return 0, nil, false
+	}
+
+	positions := make([]int, len(patternChars))
+	for i, j := last, end; i >= 0; i-- {
+		positions[i] = j
+		j = prev[i][j]
+	}
+
+	return scores[last][end], positions, true
+}
+
+// charBonus returns the bonus of matching the text's i-th character, which is higher at the start of a word.
+func charBonus(chars []string, i int) int {
+	if i == 0 {
+		return bonusBoundary
+	}
+
+	prevChar, _ := utf8.DecodeLastRuneInString(chars[i-1])
+	char, _ := utf8.DecodeRuneInString(chars[i])
+	switch {
+	case !unicode.IsLetter(prevChar) && !unicode.IsDigit(prevChar):
+		return bonusBoundary
+	case unicode.IsLower(prevChar) && unicode.IsUpper(char):
+		return bonusCamelCase
+	}
+	return 0
+}
@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+const zeroWidthJoiner = 0x200d
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
+
+// isGraphemeExtend reports whether the rune extends the previous grapheme cluster,
+// such as combining marks, variation selectors, emoji modifiers and tags.
+func isGraphemeExtend(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc) ||
+		r == zeroWidthJoiner ||
+		(r >= 0xfe00 && r <= 0xfe0f) ||
+		(r >= 0xe0100 && r <= 0xe01ef) ||
+		(r >= 0x1f3fb && r <= 0x1f3ff) ||
+		(r >= 0xe0020 && r <= 0xe007f)
+}
+
+type hangulType int
+
+const (
+	hangulNone hangulType = iota
+	hangulL
+	hangulV
+	hangulT
+	hangulLV
+	hangulLVT
+)
+
+func hangulTypeOf(r rune) hangulType {
+	switch {
+	case (r >= 0x1100 && r <= 0x115f) || (r >= 0xa960 && r <= 0xa97c):
+		return hangulL
+	case (r >= 0x1160 && r <= 0x11a7) || (r >= 0xd7b0 && r <= 0xd7c6):
+		return hangulV
+	case (r >= 0x11a8 && r <= 0x11ff) || (r >= 0xd7cb && r <= 0xd7fb):
+		return hangulT
+	case r >= 0xac00 && r <= 0xd7a3:
+		if (r-0xac00)%28 == 0 {
+			return hangulLV
+		}
+		return hangulLVT
+	}
+	return hangulNone
+}
+
+// isHangulSequence reports whether two runes belong to the same Hangul syllable.
+func isHangulSequence(prev, next rune) bool {
+	p, n := hangulTypeOf(prev), hangulTypeOf(next)
+	switch p {
+	case hangulL:
+		return n == hangulL || n == hangulV || n == hangulLV || n == hangulLVT
+	case hangulLV, hangulV:
+		return n == hangulV || n == hangulT
+	case hangulLVT, hangulT:
+		return n == hangulT
+	}
+	return false
+}
+
+// Graphemes splits a string into user-perceived characters (extended grapheme clusters),
+// keeping combining marks, emoji sequences joined by ZWJ, flags and Hangul syllables together.
+func Graphemes(str string) []string {
+	var graphemes []string
+	start := 0
+	prev := rune(-1)
+	regionalIndicators := 0
+
+	for i, r := range str {
+		if prev != -1 && isGraphemeBoundary(prev, r, regionalIndicators) {
+			graphemes = append(graphemes, str[start:i])
+			start = i
+		}
+		if isRegionalIndicator(r) {
+			regionalIndicators++
+		} else {
+			regionalIndicators = 0
+		}
+		prev = r
+	}
+	if start < len(str) {
+		graphemes = append(graphemes, str[start:])
+	}
+
+	return graphemes
+}
+
+// isGraphemeBoundary reports whether there is a grapheme cluster boundary between two runes,
+// based on a subset of the rules from Unicode Standard Annex #29.
+func isGraphemeBoundary(prev, next rune, regionalIndicators int) bool {
+	switch {
+	case prev == '\r' && next == '\n':
+		return false
+	case isControlCharacter(prev) || isControlCharacter(next):
+		return true
+	case isHangulSequence(prev, next):
+		return false
+	case isGraphemeExtend(next):
+		return false
+	case prev == zeroWidthJoiner:
+		return false
+	case isRegionalIndicator(prev) && isRegionalIndicator(next):
+		return regionalIndicators%2 == 0
+	}
+	return true
+}
+
+// GraphemeCount returns the number of grapheme clusters in a string.
+func GraphemeCount(str string) int {
+	return len(Graphemes(str))
+}
+
+// GraphemeSlice returns the grapheme clusters of a string between the start and end indexes, as a string.
+// Indexes are clamped to the bounds of the string.
+func GraphemeSlice(str string, start, end int) string {
+	graphemes := Graphemes(str)
+	end = max(min(end, len(graphemes)), 0)
+	start = max(min(start, end), 0)
+	return strings.Join(graphemes[start:end], "")
+}
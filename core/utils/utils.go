@@ -1,55 +1,208 @@
 package utils
 
+import (
+	"strings"
+	"unicode"
+)
+
 func isControlCharacter(r rune) bool {
 	return r <= 0x1f || (r >= 0x7f && r <= 0x9f)
 }
 
-func isCombiningCharacter(r rune) bool {
-	return r >= 0x300 && r <= 0x36f
+// isZeroWidth reports whether the rune takes no space on its own, such as combining marks and format characters.
+func isZeroWidth(r rune) bool {
+	return isControlCharacter(r) || unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf)
 }
 
-func isSurrogatePair(r rune) bool {
-	return r >= 0xd800 && r <= 0xdbff
+// wideRanges are the East Asian Wide and Fullwidth ranges, including emoji with default emoji presentation.
+var wideRanges = [][2]rune{
+	{0x1100, 0x115f}, {0x231a, 0x231b}, {0x2329, 0x232a}, {0x23e9, 0x23ec}, {0x23f0, 0x23f0},
+	{0x23f3, 0x23f3}, {0x25fd, 0x25fe}, {0x2614, 0x2615}, {0x2648, 0x2653}, {0x267f, 0x267f},
+	{0x2693, 0x2693}, {0x26a1, 0x26a1}, {0x26aa, 0x26ab}, {0x26bd, 0x26be}, {0x26c4, 0x26c5},
+	{0x26ce, 0x26ce}, {0x26d4, 0x26d4}, {0x26ea, 0x26ea}, {0x26f2, 0x26f3}, {0x26f5, 0x26f5},
+	{0x26fa, 0x26fa}, {0x26fd, 0x26fd}, {0x2705, 0x2705}, {0x270a, 0x270b}, {0x2728, 0x2728},
+	{0x274c, 0x274c}, {0x274e, 0x274e}, {0x2753, 0x2755}, {0x2757, 0x2757}, {0x2795, 0x2797},
+	{0x27b0, 0x27b0}, {0x27bf, 0x27bf}, {0x2b1b, 0x2b1c}, {0x2b50, 0x2b50}, {0x2b55, 0x2b55},
+	{0x2e80, 0x303e}, {0x3041, 0x33ff}, {0x3400, 0x4dbf}, {0x4e00, 0x9fff}, {0xa000, 0xa4cf},
+	{0xa960, 0xa97f}, {0xac00, 0xd7a3}, {0xf900, 0xfaff}, {0xfe10, 0xfe19}, {0xfe30, 0xfe6f},
+	{0xff00, 0xff60}, {0xffe0, 0xffe6}, {0x16fe0, 0x16fe4}, {0x17000, 0x18aff}, {0x1b000, 0x1b2ff},
+	{0x1f004, 0x1f004}, {0x1f0cf, 0x1f0cf}, {0x1f18e, 0x1f18e}, {0x1f191, 0x1f19a}, {0x1f200, 0x1f251},
+	{0x1f300, 0x1f320}, {0x1f32d, 0x1f335}, {0x1f337, 0x1f37c}, {0x1f37e, 0x1f393}, {0x1f3a0, 0x1f3ca},
+	{0x1f3cf, 0x1f3d3}, {0x1f3e0, 0x1f3f0}, {0x1f3f4, 0x1f3f4}, {0x1f3f8, 0x1f43e}, {0x1f440, 0x1f440},
+	{0x1f442, 0x1f4fc}, {0x1f4ff, 0x1f53d}, {0x1f54b, 0x1f54e}, {0x1f550, 0x1f567}, {0x1f57a, 0x1f57a},
+	{0x1f595, 0x1f596}, {0x1f5a4, 0x1f5a4}, {0x1f5fb, 0x1f64f}, {0x1f680, 0x1f6c5}, {0x1f6cc, 0x1f6cc},
+	{0x1f6d0, 0x1f6d2}, {0x1f6d5, 0x1f6d7}, {0x1f6eb, 0x1f6ec}, {0x1f6f4, 0x1f6fc}, {0x1f7e0, 0x1f7eb},
+	{0x1f90c, 0x1f93a}, {0x1f93c, 0x1f945}, {0x1f947, 0x1f9ff}, {0x1fa70, 0x1faff}, {0x20000, 0x2fffd},
+	{0x30000, 0x3fffd},
 }
 
-func StrLength(str string) int {
-	if len(str) == 0 {
+func isWide(r rune) bool {
+	if r < wideRanges[0][0] {
+		return false
+	}
+	low, high := 0, len(wideRanges)-1
+	for low <= high {
+		mid := (low + high) / 2
+		switch {
+		case r < wideRanges[mid][0]:
+			high = mid - 1
+		case r > wideRanges[mid][1]:
+			low = mid + 1
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// RuneWidth returns the number of terminal columns taken by a rune.
+func RuneWidth(r rune) int {
+	if isZeroWidth(r) {
 		return 0
 	}
+	if isWide(r) {
+		return 2
+	}
+	return 1
+}
 
-	length := 0
-	inEscapeCode := false
+// graphemeWidth returns the number of terminal columns taken by a grapheme cluster.
+func graphemeWidth(grapheme string) int {
+	runes := []rune(grapheme)
+	width := RuneWidth(runes[0])
+	if len(runes) == 1 {
+		return width
+	}
+	if isRegionalIndicator(runes[0]) && isRegionalIndicator(runes[1]) {
+		return 2
+	}
+	for _, r := range runes[1:] {
+		// Emoji presentation selector
+		if r == 0xfe0f {
+			return 2
+		}
+	}
+	return width
+}
 
-	for i := 0; i < len(str); i++ {
-		r := rune(str[i])
+type ansiToken struct {
+	text     string
+	isEscape bool
+}
 
-		if inEscapeCode {
-			if r == 'm' {
-				inEscapeCode = false
-			}
-			continue
+// tokenizeAnsi splits a string into escape sequences and plain text.
+// It recognizes CSI (`ESC [`), OSC (`ESC ]`, terminated by BEL or `ESC \`) and two-character escape sequences.
+func tokenizeAnsi(str string) []ansiToken {
+	var tokens []ansiToken
+	start := 0
+	addText := func(end int) {
+		if end > start {
+			tokens = append(tokens, ansiToken{text: str[start:end]})
 		}
+	}
 
-		if r == '\x1b' {
-			inEscapeCode = true
-			// length++ // count the escape code as 1 character
+	for i := 0; i < len(str); {
+		if str[i] != '\x1b' {
+			i++
 			continue
 		}
 
-		if isControlCharacter(r) || isCombiningCharacter(r) {
-			continue
+		addText(i)
+		end := escapeSequenceEnd(str, i)
+		tokens = append(tokens, ansiToken{text: str[i:end], isEscape: true})
+		i = end
+		start = end
+	}
+	addText(len(str))
+
+	return tokens
+}
+
+// escapeSequenceEnd returns the index after the escape sequence starting at the given index.
+func escapeSequenceEnd(str string, start int) int {
+	i := start + 1
+	if i >= len(str) {
+		return i
+	}
+
+	switch str[i] {
+	case '[':
+		for i++; i < len(str); i++ {
+			if str[i] >= 0x40 && str[i] <= 0x7e {
+				return i + 1
+			}
 		}
+		return i
+	case ']':
+		for i++; i < len(str); i++ {
+			if str[i] == '\a' {
+				return i + 1
+			}
+			if str[i] == '\x1b' && i+1 < len(str) && str[i+1] == '\\' {
+				return i + 2
+			}
+		}
+		return i
+	default:
+		return i + 1
+	}
+}
 
-		if isSurrogatePair(r) {
-			i++
+// StripAnsi removes all escape sequences from a string.
+func StripAnsi(str string) string {
+	var sb strings.Builder
+	for _, token := range tokenizeAnsi(str) {
+		if !token.isEscape {
+			sb.WriteString(token.text)
 		}
+	}
+	return sb.String()
+}
+
+// StrLength returns the display width of a string, in terminal columns.
+// Escape sequences, combining marks and zero-width characters take no space, while wide characters and emoji take two columns.
+func StrLength(str string) int {
+	if len(str) == 0 {
+		return 0
+	}
 
-		length++
+	length := 0
+	for _, grapheme := range Graphemes(StripAnsi(str)) {
+		length += graphemeWidth(grapheme)
 	}
 
 	return length
 }
 
+// SplitWidth splits a string after the last grapheme cluster that fits in the given display width.
+// Escape sequences are kept in place.
+func SplitWidth(str string, width int) (head string, tail string) {
+	tokens := tokenizeAnsi(str)
+	length := 0
+	for i, token := range tokens {
+		if token.isEscape {
+			head += token.text
+			continue
+		}
+
+		graphemes := Graphemes(token.text)
+		for j, grapheme := range graphemes {
+			graphemeLength := graphemeWidth(grapheme)
+			if length+graphemeLength > width {
+				tail = strings.Join(graphemes[j:], "")
+				for _, token := range tokens[i+1:] {
+					tail += token.text
+				}
+				return head, tail
+			}
+			head += grapheme
+			length += graphemeLength
+		}
+	}
+
+	return head, ""
+}
+
 func MinMaxIndex(index int, max int) int {
 	if index < 0 {
 		return max - 1
@@ -17,3 +17,112 @@ func TestStrLength(t *testing.T) {
 	assert.Equal(t, 5, utils.StrLength(picocolors.Green("◇")+" "+"Foo"))
 	assert.Equal(t, 5, utils.StrLength(picocolors.Green("o")+" "+"Foo"))
 }
+
+func TestGraphemes(t *testing.T) {
+	assert.Equal(t, []string(nil), utils.Graphemes(""))
+	assert.Equal(t, []string{"a", "b", "c"}, utils.Graphemes("abc"))
+	assert.Equal(t, []string{"ç", "ã", "o"}, utils.Graphemes("ção"))
+	assert.Equal(t, []string{"é", "!"}, utils.Graphemes("é!"))
+	assert.Equal(t, []string{"日", "本"}, utils.Graphemes("日本"))
+	assert.Equal(t, []string{"👍🏽", "!"}, utils.Graphemes("👍🏽!"))
+	assert.Equal(t, []string{"👨‍👩‍👧", "a"}, utils.Graphemes("👨‍👩‍👧a"))
+	assert.Equal(t, []string{"🇧🇷", "🇺🇸", "🇯"}, utils.Graphemes("🇧🇷🇺🇸🇯"))
+	assert.Equal(t, []string{"❤️", "a"}, utils.Graphemes("❤️a"))
+	assert.Equal(t, []string{"\uD55C", "\uAE00"}, utils.Graphemes("\uD55C\uAE00"))
+	assert.Equal(t, []string{"\u1112\u1161\u11AB", "\u1100\u1173\u11AF"}, utils.Graphemes("\u1112\u1161\u11AB\u1100\u1173\u11AF"))
+	assert.Equal(t, []string{"\r\n", "a"}, utils.Graphemes("\r\na"))
+}
+
+func TestGraphemeSlice(t *testing.T) {
+	assert.Equal(t, "日本", utils.GraphemeSlice("日本語", 0, 2))
+	assert.Equal(t, "語", utils.GraphemeSlice("日本語", 2, 10))
+	assert.Equal(t, "", utils.GraphemeSlice("日本語", 4, 2))
+}
+
+func TestStrLengthWidth(t *testing.T) {
+	assert.Equal(t, 0, utils.StrLength(""))
+	assert.Equal(t, 3, utils.StrLength("abc"))
+	assert.Equal(t, 3, utils.StrLength("ção"))
+	assert.Equal(t, 1, utils.StrLength("é"))
+	assert.Equal(t, 4, utils.StrLength("日本"))
+	assert.Equal(t, 4, utils.StrLength("ｆｕ"))
+	assert.Equal(t, 2, utils.StrLength("👍"))
+	assert.Equal(t, 2, utils.StrLength("👍🏽"))
+	assert.Equal(t, 2, utils.StrLength("👨‍👩‍👧"))
+	assert.Equal(t, 2, utils.StrLength("🇧🇷"))
+	assert.Equal(t, 2, utils.StrLength("❤️"))
+	assert.Equal(t, 1, utils.StrLength("❤"))
+	assert.Equal(t, 2, utils.StrLength("a​b"))
+}
+
+func TestStrLengthEscapeSequences(t *testing.T) {
+	assert.Equal(t, 3, utils.StrLength("\x1b[31mabc\x1b[39m"))
+	assert.Equal(t, 3, utils.StrLength("\x1b[38;5;196mabc\x1b[0m"))
+	assert.Equal(t, 4, utils.StrLength("\x1b[1m日本\x1b[22m"))
+	assert.Equal(t, 4, utils.StrLength("\x1b]8;;https://example.com\x07link\x1b]8;;\x07"))
+	assert.Equal(t, 4, utils.StrLength("\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\"))
+	assert.Equal(t, 3, utils.StrLength("\x1b[2K\x1b[1Aabc"))
+	assert.Equal(t, 3, utils.StrLength("\x1b7abc\x1b8"))
+}
+
+func TestStripAnsi(t *testing.T) {
+	assert.Equal(t, "abc", utils.StripAnsi("\x1b[31mabc\x1b[39m"))
+	assert.Equal(t, "link", utils.StripAnsi("\x1b]8;;https://example.com\x07link\x1b]8;;\x07"))
+}
+
+func TestSplitWidth(t *testing.T) {
+	head, tail := utils.SplitWidth("abcdef", 4)
+	assert.Equal(t, "abcd", head)
+	assert.Equal(t, "ef", tail)
+
+	head, tail = utils.SplitWidth("日本語", 3)
+	assert.Equal(t, "日", head)
+	assert.Equal(t, "本語", tail)
+
+	head, tail = utils.SplitWidth("\x1b[31mabc\x1b[39m", 2)
+	assert.Equal(t, "\x1b[31mab", head)
+	assert.Equal(t, "c\x1b[39m", tail)
+
+	head, tail = utils.SplitWidth("abc", 5)
+	assert.Equal(t, "abc", head)
+	assert.Equal(t, "", tail)
+}
+
+func TestFuzzyMatch(t *testing.T) {
+	score, positions, ok := utils.FuzzyMatch("", "foo")
+	assert.True(t, ok)
+	assert.Equal(t, 0, score)
+	assert.Nil(t, positions)
+
+	_, positions, ok = utils.FuzzyMatch("fb", "foo-bar")
+	assert.True(t, ok)
+	assert.Equal(t, []int{0, 4}, positions)
+
+	_, positions, ok = utils.FuzzyMatch("BAR", "foobar-bar")
+	assert.True(t, ok)
+	assert.Equal(t, []int{7, 8, 9}, positions)
+
+	_, positions, ok = utils.FuzzyMatch("gm", "goMod")
+	assert.True(t, ok)
+	assert.Equal(t, []int{0, 2}, positions)
+
+	_, _, ok = utils.FuzzyMatch("(", "foo")
+	assert.False(t, ok)
+
+	_, _, ok = utils.FuzzyMatch("ba", "ab")
+	assert.False(t, ok)
+}
+
+func TestFuzzyMatchScore(t *testing.T) {
+	consecutive, _, _ := utils.FuzzyMatch("bar", "bar")
+	scattered, _, _ := utils.FuzzyMatch("bar", "b-a-r")
+	assert.Greater(t, consecutive, scattered)
+
+	wordStart, _, _ := utils.FuzzyMatch("b", "foo bar")
+	wordMiddle, _, _ := utils.FuzzyMatch("b", "foobar")
+	assert.Greater(t, wordStart, wordMiddle)
+
+	near, _, _ := utils.FuzzyMatch("ab", "a-b")
+	far, _, _ := utils.FuzzyMatch("ab", "a-------b")
+	assert.Greater(t, near, far)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -21,8 +22,8 @@ func ChangesetExample() {
 	var changeset Changeset
 
 	err := prompts.Workflow(&changeset).
-		Step("packages", func() (any, error) {
-			return prompts.GroupMultiSelect(prompts.GroupMultiSelectParams[string]{
+		StepContext("packages", func(ctx context.Context) (any, error) {
+			return prompts.GroupMultiSelectContext(ctx, prompts.GroupMultiSelectParams[string]{
 				Message: "Which packages would you like to include?",
 				Options: map[string][]prompts.MultiSelectOption[string]{
 					"changed packages": {
@@ -38,19 +39,19 @@ func ChangesetExample() {
 				},
 			})
 		}).
-		Step("major", func() (any, error) {
+		StepContext("major", func(ctx context.Context) (any, error) {
 			majorOptions := make([]*prompts.MultiSelectOption[string], len(changeset.Packages))
 			for i, packageOption := range changeset.Packages {
 				majorOptions[i] = &prompts.MultiSelectOption[string]{
 					Label: packageOption,
 				}
 			}
-			return prompts.MultiSelect(prompts.MultiSelectParams[string]{
+			return prompts.MultiSelectContext(ctx, prompts.MultiSelectParams[string]{
 				Message: fmt.Sprintf("Which packages should have a %s bump?", picocolors.Red("major")),
 				Options: majorOptions,
 			})
 		}).
-		Step("minor", func() (any, error) {
+		StepContext("minor", func(ctx context.Context) (any, error) {
 			minorOptions := []*prompts.MultiSelectOption[string]{}
 		packagesLoop:
 			for _, packageOption := range changeset.Packages {
@@ -64,12 +65,14 @@ func ChangesetExample() {
 				})
 			}
 
-			return prompts.MultiSelect(prompts.MultiSelectParams[string]{
+			return prompts.MultiSelectContext(ctx, prompts.MultiSelectParams[string]{
 				Message: fmt.Sprintf("Which packages should have a %s bump?", picocolors.Yellow("minor")),
 				Options: minorOptions,
 			})
 		}).
-		Step("patch", func() (any, error) {
+		// The patch packages are logged, so going back from the summary returns to the minor packages
+		LogStep("patch", func() {
+			changeset.Patch = nil
 		packagesLoop:
 			for _, _package := range changeset.Packages {
 				for _, majorPackage := range changeset.Major {
@@ -89,11 +92,9 @@ func ChangesetExample() {
 				note := strings.Join(changeset.Patch, picocolors.Dim(", "))
 				prompts.Step(fmt.Sprintf("These packages will have a %s bump: %s", picocolors.Green("patch"), picocolors.Dim(note)))
 			}
-
-			return changeset.Patch, nil
 		}).
-		Step("summary", func() (any, error) {
-			return prompts.Text(prompts.TextParams{
+		StepContext("summary", func(ctx context.Context) (any, error) {
+			return prompts.TextContext(ctx, prompts.TextParams{
 				Message:     "Please enter a summary for this change",
 				Placeholder: "Summary",
 			})
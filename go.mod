@@ -5,12 +5,12 @@ go 1.22.1
 require (
 	github.com/bradleyjkemp/cupaloy v2.3.0+incompatible
 	github.com/stretchr/testify v1.9.0
+	golang.org/x/sys v0.20.0
 	golang.org/x/term v0.20.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	golang.org/x/sys v0.20.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,169 @@
+package prompts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/Mist3rBru/go-clack/core"
+	"gopkg.in/yaml.v3"
+)
+
+// Answers is a source of pre-supplied answers, looked up by prompt or workflow step name.
+// A prompt with a provided answer is validated and submitted without waiting for input.
+type Answers interface {
+	Lookup(name string) (answer any, ok bool)
+}
+
+// MapAnswers is an answer source backed by a map.
+type MapAnswers map[string]any
+
+// Lookup looks up an answer by name, falling back to a case-insensitive match.
+// A dotted name like `database.host` is also looked up in nested maps.
+func (a MapAnswers) Lookup(name string) (any, bool) {
+	if answer, ok := a.lookup(name); ok {
+		return answer, true
+	}
+	if section, rest, ok := strings.Cut(name, "."); ok {
+		if answer, ok := a.lookup(section); ok {
+			if m, ok := answer.(map[string]any); ok {
+				return MapAnswers(m).Lookup(rest)
+			}
+		}
+	}
+	return nil, false
+}
+
+func (a MapAnswers) lookup(name string) (any, bool) {
+	if answer, ok := a[name]; ok {
+		return answer, true
+	}
+	for key, answer := range a {
+		if strings.EqualFold(key, name) {
+			return answer, true
+		}
+	}
+	return nil, false
+}
+
+type envAnswers struct {
+	prefix string
+}
+
+// EnvAnswers returns an answer source backed by environment variables.
+// A name like `projectName` is looked up as `<PREFIX>_PROJECT_NAME`.
+func EnvAnswers(prefix string) Answers {
+	return envAnswers{prefix: prefix}
+}
+
+func (a envAnswers) Lookup(name string) (any, bool) {
+	key := envName(name)
+	if a.prefix != "" {
+		key = strings.TrimSuffix(a.prefix, "_") + "_" + key
+	}
+	return os.LookupEnv(key)
+}
+
+func envName(name string) string {
+	var sb strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case unicode.IsUpper(r) && i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])):
+			sb.WriteRune('_')
+			sb.WriteRune(r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			sb.WriteRune(unicode.ToUpper(r))
+		default:
+			sb.WriteRune('_')
+		}
+	}
+	return sb.String()
+}
+
+// FileAnswers returns an answer source backed by a JSON or YAML file, chosen by its extension.
+func FileAnswers(path string) (Answers, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	answers := MapAnswers{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &answers)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &answers)
+	default:
+		return nil, fmt.Errorf("unsupported answers file: %s", path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return answers, nil
+}
+
+type answersKey struct{}
+
+// WithAnswers returns a copy of the context that carries an answer source,
+// consulted by prompts run with that context and a `Name`.
+func WithAnswers(ctx context.Context, answers Answers) context.Context {
+	return context.WithValue(ctx, answersKey{}, answers)
+}
+
+func lookupAnswer(ctx context.Context, name string) (any, bool) {
+	if answers, ok := ctx.Value(answersKey{}).(Answers); ok && answers != nil && name != "" {
+		return answers.Lookup(name)
+	}
+	return nil, false
+}
+
+// runPrompt submits the prompt's pre-supplied answer, if there is one, or runs it otherwise.
+// Run with a workflow step's context, the prompt is pre-filled with the step's previous answer
+// and can go back to the previous step.
+func runPrompt[TValue any](ctx context.Context, p *core.Prompt[TValue], name string) (TValue, error) {
+	return runStepPrompt(ctx, p, name, isBackKey)
+}
+
+// runStepPrompt runs the prompt like runPrompt, going back to the previous workflow step on the keys matched by isBack.
+func runStepPrompt[TValue any](ctx context.Context, p *core.Prompt[TValue], name string, isBack func(key *core.Key) bool) (TValue, error) {
+	step := stepState(ctx)
+	if step != nil && step.hasAnswer {
+		return p.SubmitAnswer(step.answer)
+	}
+	if step == nil || !step.isRevisited {
+		if answer, ok := lookupAnswer(ctx, name); ok {
+			return p.SubmitAnswer(answer)
+		}
+	}
+	if step == nil {
+		return p.RunContext(ctx)
+	}
+
+	if step.hasInitialValue {
+		p.SetAnswer(step.initialValue)
+	}
+
+	isGoingBack := false
+	if step.canGoBack {
+		p.InterceptKey = func(key *core.Key) bool {
+			if !isBack(key) {
+				return false
+			}
+			isGoingBack = true
+			p.State = core.CancelState
+			return true
+		}
+	}
+
+	value, err := p.RunContext(ctx)
+	if isGoingBack {
+		return value, ErrBack
+	}
+	return value, err
+}
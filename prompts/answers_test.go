@@ -0,0 +1,232 @@
+package prompts_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/Mist3rBru/go-clack/core"
+	"github.com/Mist3rBru/go-clack/prompts"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapAnswers(t *testing.T) {
+	answers := prompts.MapAnswers{"name": "foo"}
+
+	answer, ok := answers.Lookup("name")
+	assert.True(t, ok)
+	assert.Equal(t, "foo", answer)
+
+	_, ok = answers.Lookup("age")
+	assert.False(t, ok)
+}
+
+func TestMapAnswersWithNestedMap(t *testing.T) {
+	answers := prompts.MapAnswers{
+		"database":  map[string]any{"host": "localhost"},
+		"cache.ttl": "1m",
+	}
+
+	answer, ok := answers.Lookup("database.host")
+	assert.True(t, ok)
+	assert.Equal(t, "localhost", answer)
+
+	answer, ok = answers.Lookup("Database.Host")
+	assert.True(t, ok)
+	assert.Equal(t, "localhost", answer)
+
+	answer, ok = answers.Lookup("cache.ttl")
+	assert.True(t, ok)
+	assert.Equal(t, "1m", answer)
+
+	_, ok = answers.Lookup("database.port")
+	assert.False(t, ok)
+}
+
+func TestEnvAnswers(t *testing.T) {
+	t.Setenv("APP_PROJECT_NAME", "foo")
+	t.Setenv("APP_TOOLS", "eslint,prettier")
+
+	answers := prompts.EnvAnswers("APP")
+
+	answer, ok := answers.Lookup("projectName")
+	assert.True(t, ok)
+	assert.Equal(t, "foo", answer)
+
+	answer, ok = answers.Lookup("tools")
+	assert.True(t, ok)
+	assert.Equal(t, "eslint,prettier", answer)
+
+	_, ok = answers.Lookup("missing")
+	assert.False(t, ok)
+}
+
+func TestFileAnswers(t *testing.T) {
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "answers.json")
+	yamlPath := filepath.Join(dir, "answers.yaml")
+	os.WriteFile(jsonPath, []byte(`{"name": "foo", "install": true, "tools": ["eslint"]}`), 0o644)
+	os.WriteFile(yamlPath, []byte("name: foo\ninstall: true\ntools:\n  - eslint\n"), 0o644)
+
+	for _, path := range []string{jsonPath, yamlPath} {
+		answers, err := prompts.FileAnswers(path)
+		assert.NoError(t, err)
+
+		answer, _ := answers.Lookup("name")
+		assert.Equal(t, "foo", answer)
+		answer, _ = answers.Lookup("install")
+		assert.Equal(t, true, answer)
+		answer, _ = answers.Lookup("tools")
+		assert.Equal(t, []any{"eslint"}, answer)
+	}
+
+	_, err := prompts.FileAnswers(filepath.Join(dir, "answers.txt"))
+	assert.Error(t, err)
+}
+
+func TestPromptWithAnswer(t *testing.T) {
+	ctx := prompts.WithAnswers(context.Background(), prompts.MapAnswers{
+		"name":    "foo",
+		"install": true,
+		"tool":    "Prettier",
+		"tools":   []any{"eslint", "prettier"},
+	})
+
+	name, err := prompts.TextContext(ctx, prompts.TextParams{Name: "name", Message: "Name"})
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", name)
+
+	install, err := prompts.ConfirmContext(ctx, prompts.ConfirmParams{Name: "install", Message: "Install"})
+	assert.NoError(t, err)
+	assert.Equal(t, true, install)
+
+	tool, err := prompts.SelectContext(ctx, prompts.SelectParams[string]{
+		Name:    "tool",
+		Message: "Tool",
+		Options: []*prompts.SelectOption[string]{
+			{Label: "Eslint", Value: "eslint"},
+			{Label: "Prettier", Value: "prettier"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "prettier", tool)
+
+	tools, err := prompts.MultiSelectContext(ctx, prompts.MultiSelectParams[string]{
+		Name:    "tools",
+		Message: "Tools",
+		Options: []*prompts.MultiSelectOption[string]{
+			{Label: "Eslint", Value: "eslint"},
+			{Label: "Prettier", Value: "prettier"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"eslint", "prettier"}, tools)
+}
+
+func TestPromptWithInvalidAnswer(t *testing.T) {
+	ctx := prompts.WithAnswers(context.Background(), prompts.MapAnswers{"name": "foo"})
+
+	_, err := prompts.TextContext(ctx, prompts.TextParams{
+		Name:    "name",
+		Message: "Name",
+		Validate: func(value string) error {
+			return errors.New("invalid name")
+		},
+	})
+	assert.ErrorIs(t, err, core.ErrInvalidAnswer)
+	assert.ErrorContains(t, err, "invalid name")
+}
+
+func TestWorkflowWithAnswers(t *testing.T) {
+	var r struct {
+		Name    string
+		Install bool
+	}
+	var canceledStep string
+
+	err := prompts.Workflow(&r).
+		Answers(prompts.MapAnswers{"name": "foo", "install": "yes"}).
+		StepContext("name", func(ctx context.Context) (any, error) {
+			return prompts.TextContext(ctx, prompts.TextParams{Message: "Name"})
+		}).
+		StepContext("install", func(ctx context.Context) (any, error) {
+			return prompts.ConfirmContext(ctx, prompts.ConfirmParams{Message: "Install"})
+		}).
+		Run()
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", r.Name)
+	assert.Equal(t, true, r.Install)
+
+	err = prompts.Workflow(&r).
+		Answers(prompts.MapAnswers{"install": "maybe"}).
+		StepContext("install", func(ctx context.Context) (any, error) {
+			return prompts.ConfirmContext(ctx, prompts.ConfirmParams{Message: "Install"})
+		}).
+		OnCancel(func(step string, err error) { canceledStep = step }).
+		Run()
+	assert.ErrorIs(t, err, core.ErrInvalidAnswer)
+	assert.Equal(t, "install", canceledStep)
+}
+
+func TestWorkflowWithAnswersForPlainSteps(t *testing.T) {
+	var r struct {
+		Name  string
+		Tools []string
+		Age   int
+	}
+	var prompted int
+
+	err := prompts.Workflow(&r).
+		Answers(prompts.MapAnswers{"name": "foo", "tools": []any{"eslint", "jest"}}).
+		Step("name", func() (any, error) {
+			prompted++
+			return "prompted", nil
+		}).
+		Step("tools", func() (any, error) {
+			prompted++
+			return []string{}, nil
+		}).
+		Run()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, prompted)
+	assert.Equal(t, "foo", r.Name)
+	assert.Equal(t, []string{"eslint", "jest"}, r.Tools)
+
+	err = prompts.Workflow(&r).
+		Answers(prompts.MapAnswers{"age": "22"}).
+		Step("age", func() (any, error) {
+			prompted++
+			return 0, nil
+		}).
+		Run()
+	assert.ErrorContains(t, err, "answer for step `age` requires StepContext")
+	assert.Equal(t, 0, prompted)
+}
+
+func TestConcurrentWorkflowsWithAnswers(t *testing.T) {
+	names := []string{"foo", "bar", "baz"}
+	results := make([]string, len(names))
+	var wg sync.WaitGroup
+
+	for i, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var r struct{ Name string }
+			err := prompts.Workflow(&r).
+				Answers(prompts.MapAnswers{"name": name}).
+				StepContext("name", func(ctx context.Context) (any, error) {
+					return prompts.TextContext(ctx, prompts.TextParams{Message: "Name"})
+				}).
+				Run()
+			assert.NoError(t, err)
+			results[i] = r.Name
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, names, results)
+}
@@ -0,0 +1,112 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Mist3rBru/go-clack/core"
+	"github.com/Mist3rBru/go-clack/prompts/symbols"
+	"github.com/Mist3rBru/go-clack/prompts/test"
+	"github.com/Mist3rBru/go-clack/prompts/theme"
+	isunicodesupported "github.com/Mist3rBru/go-clack/third_party/is-unicode-supported"
+	"github.com/Mist3rBru/go-clack/third_party/picocolors"
+)
+
+type AsyncSelectParams[TValue comparable] struct {
+	Name         string
+	Message      string
+	InitialValue TValue
+	// Load returns the options for the typed search, and its context is cancelled once the search changes again
+	Load     func(ctx context.Context, query string) ([]*SelectOption[TValue], error)
+	Debounce time.Duration
+	Required bool
+}
+
+func AsyncSelect[TValue comparable](params AsyncSelectParams[TValue]) (TValue, error) {
+	return AsyncSelectContext(context.Background(), params)
+}
+
+func AsyncSelectContext[TValue comparable](ctx context.Context, params AsyncSelectParams[TValue]) (TValue, error) {
+	var load func(ctx context.Context, query string) ([]*core.SelectOption[TValue], error)
+	if params.Load != nil {
+		load = func(ctx context.Context, query string) ([]*core.SelectOption[TValue], error) {
+			loadedOptions, err := params.Load(ctx, query)
+			if err != nil {
+				return nil, err
+			}
+
+			var options []*core.SelectOption[TValue]
+			for _, option := range loadedOptions {
+				coreOption := &core.SelectOption[TValue]{
+					Label: option.Label,
+					Value: option.Value,
+					Hint:  option.Hint,
+				}
+				options = append(options, coreOption)
+			}
+			return options, nil
+		}
+	}
+
+	frames := []string{"◒", "◐", "◓", "◑"}
+	if !isunicodesupported.IsUnicodeSupported() {
+		frames = []string{"•", "o", "O", "0"}
+	}
+
+	p := core.NewAsyncSelectPrompt(core.AsyncSelectPromptParams[TValue]{
+		InitialValue: params.InitialValue,
+		Load:         load,
+		Debounce:     params.Debounce,
+		Required:     params.Required,
+		Render: func(p *core.AsyncSelectPrompt[TValue]) string {
+			message := params.Message
+			var value string
+
+			switch p.State {
+			case core.SubmitState, core.CancelState:
+				if p.CursorIndex >= 0 && p.CursorIndex < len(p.Options) {
+					value = p.Options[p.CursorIndex].Label
+				}
+			default:
+				if p.Search == "" {
+					message = fmt.Sprintf("%s\n> %s", message, picocolors.Inverse("T")+picocolors.Dim("ype to search..."))
+				} else {
+					message = fmt.Sprintf("%s\n> %s", message, p.Search+picocolors.Inverse(" "))
+				}
+
+				switch {
+				case p.IsLoading:
+					frame := frames[int(p.LoadingDuration/(125*time.Millisecond))%len(frames)]
+					value = fmt.Sprintf("%s %s", picocolors.Magenta(frame), picocolors.Dim("Loading..."))
+				case p.LoadError != "":
+					value = picocolors.Red(fmt.Sprintf("%s %s", symbols.STEP_ERROR, p.LoadError))
+				case len(p.Options) == 0:
+					value = picocolors.Dim("No results found")
+				default:
+					radioOptions := make([]string, len(p.Options))
+					for i, option := range p.Options {
+						if i == p.CursorIndex {
+							radioOptions[i] = fmt.Sprintf("%s %s", picocolors.Green(symbols.RADIO_ACTIVE), option.Label)
+							if option.Hint != "" {
+								radioOptions[i] += " " + picocolors.Dim("("+option.Hint+")")
+							}
+						} else {
+							radioOptions[i] = fmt.Sprintf("%s %s", picocolors.Dim(symbols.RADIO_INACTIVE), picocolors.Dim(option.Label))
+						}
+					}
+					value = p.LimitLines(radioOptions, 4)
+				}
+			}
+
+			return theme.ApplyTheme(theme.ThemeParams[TValue]{
+				Ctx:             p.Prompt,
+				Message:         message,
+				Value:           value,
+				ValueWithCursor: value,
+			})
+		},
+	})
+	test.AsyncSelectTestingPrompt = p
+	return runPrompt(ctx, &p.Prompt, params.Name)
+}
@@ -0,0 +1,101 @@
+package prompts_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mist3rBru/go-clack/core"
+	"github.com/Mist3rBru/go-clack/prompts"
+	"github.com/Mist3rBru/go-clack/prompts/symbols"
+	"github.com/Mist3rBru/go-clack/prompts/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func loadPackages(ctx context.Context, query string) ([]*prompts.SelectOption[string], error) {
+	var options []*prompts.SelectOption[string]
+	for _, option := range []*prompts.SelectOption[string]{
+		{Label: "go-clack", Hint: "v1.0.0"},
+		{Label: "go-cmp"},
+		{Label: "testify"},
+	} {
+		if strings.HasPrefix(option.Label, query) {
+			options = append(options, option)
+		}
+	}
+	return options, nil
+}
+
+func runAsyncSelect(t *testing.T, params prompts.AsyncSelectParams[string]) (*core.AsyncSelectPrompt[string], <-chan string) {
+	test.AsyncSelectTestingPrompt = nil
+	params.Message = message
+	params.Debounce = time.Millisecond
+	result := make(chan string, 1)
+	go func() {
+		value, _ := prompts.AsyncSelect(params)
+		result <- value
+	}()
+
+	assert.Eventually(t, func() bool { return test.AsyncSelectTestingPrompt != nil }, time.Second, time.Millisecond)
+	p := test.AsyncSelectTestingPrompt.(*core.AsyncSelectPrompt[string])
+	assert.Eventually(t, func() bool { return p.Frame != "" }, time.Second, time.Millisecond)
+	return p, result
+}
+
+func TestAsyncSelectLoadedState(t *testing.T) {
+	p, _ := runAsyncSelect(t, prompts.AsyncSelectParams[string]{Load: loadPackages})
+
+	expected := strings.Join([]string{
+		symbols.BAR,
+		symbols.STEP_ACTIVE + " " + message,
+		symbols.BAR + " > Type to search...",
+		symbols.BAR + " " + symbols.RADIO_ACTIVE + " go-clack (v1.0.0)",
+		symbols.BAR + " " + symbols.RADIO_INACTIVE + " go-cmp",
+		symbols.BAR + " " + symbols.RADIO_INACTIVE + " testify",
+		symbols.BAR_END,
+	}, "\r\n")
+	assert.Eventually(t, func() bool { return p.Frame == expected }, time.Second, time.Millisecond)
+}
+
+func TestAsyncSelectLoadingState(t *testing.T) {
+	p, _ := runAsyncSelect(t, prompts.AsyncSelectParams[string]{
+		Load: func(ctx context.Context, query string) ([]*prompts.SelectOption[string], error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	})
+
+	assert.Contains(t, p.Frame, "Loading...")
+}
+
+func TestAsyncSelectErrorState(t *testing.T) {
+	p, _ := runAsyncSelect(t, prompts.AsyncSelectParams[string]{
+		Load: func(ctx context.Context, query string) ([]*prompts.SelectOption[string], error) {
+			return nil, errors.New("registry is down")
+		},
+	})
+
+	assert.Eventually(t, func() bool {
+		return strings.Contains(p.Frame, symbols.BAR+" "+symbols.STEP_ERROR+" registry is down")
+	}, time.Second, time.Millisecond)
+}
+
+func TestAsyncSelectSubmitState(t *testing.T) {
+	p, result := runAsyncSelect(t, prompts.AsyncSelectParams[string]{Load: loadPackages})
+
+	p.PressKey(&core.Key{Char: "t"})
+	assert.Eventually(t, func() bool {
+		return strings.Contains(p.Frame, symbols.RADIO_ACTIVE+" testify")
+	}, time.Second, time.Millisecond)
+	p.PressKey(&core.Key{Name: core.EnterKey})
+
+	expected := strings.Join([]string{
+		symbols.BAR,
+		symbols.STEP_SUBMIT + " " + message,
+		symbols.BAR + " testify",
+	}, "\r\n")
+	assert.Equal(t, expected, p.Frame)
+	assert.Equal(t, "testify", <-result)
+}
@@ -0,0 +1,86 @@
+package prompts
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Mist3rBru/go-clack/core"
+	"github.com/Mist3rBru/go-clack/core/utils"
+	"github.com/Mist3rBru/go-clack/prompts/test"
+	"github.com/Mist3rBru/go-clack/prompts/theme"
+	"github.com/Mist3rBru/go-clack/third_party/picocolors"
+)
+
+type Suggestion struct {
+	Value       string
+	Description string
+}
+
+type AutocompleteParams struct {
+	Name         string
+	Message      string
+	InitialValue string
+	Placeholder  string
+	Required     bool
+	Suggest      func(input string) []Suggestion
+	Validate     func(value string) error
+}
+
+func Autocomplete(params AutocompleteParams) (string, error) {
+	return AutocompleteContext(context.Background(), params)
+}
+
+func AutocompleteContext(ctx context.Context, params AutocompleteParams) (string, error) {
+	var suggest func(input string) []core.Suggestion
+	if params.Suggest != nil {
+		suggest = func(input string) []core.Suggestion {
+			var suggestions []core.Suggestion
+			for _, suggestion := range params.Suggest(input) {
+				suggestions = append(suggestions, core.Suggestion(suggestion))
+			}
+			return suggestions
+		}
+	}
+
+	p := core.NewAutocompletePrompt(core.AutocompletePromptParams{
+		InitialValue: params.InitialValue,
+		Placeholder:  params.Placeholder,
+		Required:     params.Required,
+		Suggest:      suggest,
+		Validate:     params.Validate,
+		Render: func(p *core.AutocompletePrompt) string {
+			valueWithCursor := p.ValueWithCursor()
+			if p.Value == "" && p.Placeholder != "" {
+				placeholder := utils.Graphemes(p.Placeholder)
+				valueWithCursor = picocolors.Inverse(placeholder[0]) + picocolors.Dim(strings.Join(placeholder[1:], ""))
+			}
+
+			if len(p.Suggestions) > 0 {
+				lines := make([]string, len(p.Suggestions))
+				for i, suggestion := range p.Suggestions {
+					line := picocolors.Dim(suggestion.Value)
+					if i == p.SuggestionIndex {
+						line = picocolors.Cyan(suggestion.Value)
+					}
+					if suggestion.Description != "" {
+						line += " " + picocolors.Dim("("+suggestion.Description+")")
+					}
+					lines[i] = line
+				}
+				valueWithCursor += "\n" + p.LimitSuggestions(lines, 4)
+			}
+
+			return theme.ApplyTheme(theme.ThemeParams[string]{
+				Ctx:             p.Prompt,
+				Message:         params.Message,
+				Value:           p.Value,
+				ValueWithCursor: valueWithCursor,
+			})
+		},
+	})
+	test.AutocompleteTestingPrompt = p
+	// Shift+Tab highlights the previous suggestion, so only Escape goes back to the previous workflow step
+	return runStepPrompt(ctx, &p.Prompt, params.Name, func(key *core.Key) bool {
+		return key.Name == core.EscapeKey
+	})
+}
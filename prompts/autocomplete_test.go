@@ -0,0 +1,127 @@
+package prompts_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mist3rBru/go-clack/core"
+	"github.com/Mist3rBru/go-clack/prompts"
+	"github.com/Mist3rBru/go-clack/prompts/symbols"
+	"github.com/Mist3rBru/go-clack/prompts/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func suggestBranches(input string) []prompts.Suggestion {
+	var suggestions []prompts.Suggestion
+	for _, suggestion := range []prompts.Suggestion{
+		{Value: "main", Description: "default"},
+		{Value: "master"},
+		{Value: "develop"},
+	} {
+		if strings.HasPrefix(suggestion.Value, input) {
+			suggestions = append(suggestions, suggestion)
+		}
+	}
+	return suggestions
+}
+
+func runAutocomplete(t *testing.T, params prompts.AutocompleteParams) (*core.AutocompletePrompt, <-chan string) {
+	test.AutocompleteTestingPrompt = nil
+	result := make(chan string, 1)
+	go func() {
+		value, _ := prompts.Autocomplete(params)
+		result <- value
+	}()
+
+	assert.Eventually(t, func() bool { return test.AutocompleteTestingPrompt != nil }, time.Second, time.Millisecond)
+	return test.AutocompleteTestingPrompt, result
+}
+
+func TestAutocompleteInitialState(t *testing.T) {
+	p, _ := runAutocomplete(t, prompts.AutocompleteParams{
+		Message:     message,
+		Placeholder: "branch",
+		Suggest:     suggestBranches,
+	})
+
+	expected := strings.Join([]string{
+		symbols.BAR,
+		symbols.State(core.InitialState) + " " + message,
+		symbols.BAR + " branch",
+		symbols.BAR + " main (default)",
+		symbols.BAR + " master",
+		symbols.BAR + " develop",
+		symbols.BAR_END,
+	}, "\r\n")
+	assert.Equal(t, expected, p.Frame)
+}
+
+func TestAutocompleteSubmitState(t *testing.T) {
+	p, result := runAutocomplete(t, prompts.AutocompleteParams{Message: message, Suggest: suggestBranches})
+
+	p.PressKey(&core.Key{Char: "m"})
+	p.PressKey(&core.Key{Name: core.TabKey, Shift: true})
+
+	activeValue := strings.Join([]string{
+		symbols.BAR + " master",
+		symbols.BAR + " main (default)",
+		symbols.BAR + " master",
+	}, "\r\n")
+	assert.Contains(t, p.Frame, activeValue)
+
+	p.PressKey(&core.Key{Name: core.EnterKey})
+
+	expected := strings.Join([]string{
+		symbols.BAR,
+		symbols.State(core.SubmitState) + " " + message,
+		symbols.BAR + " master",
+	}, "\r\n")
+	assert.Equal(t, expected, p.Frame)
+	assert.Equal(t, "master", <-result)
+}
+
+func TestAutocompleteFreeText(t *testing.T) {
+	p, result := runAutocomplete(t, prompts.AutocompleteParams{Message: message, Suggest: suggestBranches})
+
+	p.PressKey(&core.Key{Char: "x"})
+	p.PressKey(&core.Key{Name: core.EnterKey})
+
+	assert.Equal(t, "x", <-result)
+}
+
+func TestAutocompleteShiftTabInWorkflow(t *testing.T) {
+	var r struct{ Name, Branch string }
+	done := make(chan error)
+	prev := test.TextTestingPrompt
+	test.AutocompleteTestingPrompt = nil
+
+	go func() {
+		done <- prompts.Workflow(&r).
+			StepContext("Name", func(ctx context.Context) (any, error) {
+				return prompts.TextContext(ctx, prompts.TextParams{Message: "Name", InitialValue: "foo"})
+			}).
+			StepContext("Branch", func(ctx context.Context) (any, error) {
+				return prompts.AutocompleteContext(ctx, prompts.AutocompleteParams{Message: "Branch", Suggest: suggestBranches})
+			}).
+			Run()
+	}()
+
+	waitTextPrompt(t, prev).PressKey(&core.Key{Name: core.EnterKey})
+	assert.Eventually(t, func() bool { return test.AutocompleteTestingPrompt != nil }, time.Second, time.Millisecond)
+	p := test.AutocompleteTestingPrompt
+
+	p.PressKey(&core.Key{Name: core.TabKey, Shift: true})
+	assert.Equal(t, core.ActiveState, p.State)
+	assert.Equal(t, 2, p.SuggestionIndex)
+	p.PressKey(&core.Key{Name: core.EnterKey})
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		assert.FailNow(t, "workflow did not finish")
+	}
+	assert.Equal(t, "develop", r.Branch)
+}
@@ -0,0 +1,79 @@
+package prompts
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// Checkpoint is the saved progress of a workflow.
+type Checkpoint struct {
+	Result    json.RawMessage `json:"result"`
+	Completed []int           `json:"completed"`
+	// Forks are the results of the completed sub-workflows, by the index of their fork step
+	Forks map[int]json.RawMessage `json:"forks,omitempty"`
+}
+
+// CheckpointStore persists the progress of a workflow, so it can be resumed later.
+// Load returns a nil checkpoint if there is none.
+type CheckpointStore interface {
+	Save(checkpoint *Checkpoint) error
+	Load() (*Checkpoint, error)
+	Clear() error
+}
+
+type fileCheckpoint struct {
+	path string
+}
+
+// FileCheckpoint returns a checkpoint store backed by a JSON file.
+func FileCheckpoint(path string) CheckpointStore {
+	return fileCheckpoint{path: path}
+}
+
+func (c fileCheckpoint) Save(checkpoint *Checkpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first, so an interrupted save does not corrupt the checkpoint
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path)
+}
+
+func (c fileCheckpoint) Load() (*Checkpoint, error) {
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var checkpoint Checkpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, err
+	}
+	return &checkpoint, nil
+}
+
+func (c fileCheckpoint) Clear() error {
+	if err := os.Remove(c.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
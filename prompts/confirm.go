@@ -1,6 +1,7 @@
 package prompts
 
 import (
+	"context"
 	"strings"
 
 	"github.com/Mist3rBru/go-clack/core"
@@ -11,6 +12,7 @@ import (
 )
 
 type ConfirmParams struct {
+	Name         string
 	Message      string
 	InitialValue bool
 	Active       string
@@ -18,6 +20,10 @@ type ConfirmParams struct {
 }
 
 func Confirm(params ConfirmParams) (bool, error) {
+	return ConfirmContext(context.Background(), params)
+}
+
+func ConfirmContext(ctx context.Context, params ConfirmParams) (bool, error) {
 	p := core.NewConfirmPrompt(core.ConfirmPromptParams{
 		InitialValue: params.InitialValue,
 		Active:       params.Active,
@@ -45,5 +51,5 @@ func Confirm(params ConfirmParams) (bool, error) {
 		},
 	})
 	test.ConfirmTestingPrompt = p
-	return p.Run()
+	return runPrompt(ctx, &p.Prompt, params.Name)
 }
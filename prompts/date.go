@@ -0,0 +1,72 @@
+package prompts
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Mist3rBru/go-clack/core"
+	"github.com/Mist3rBru/go-clack/prompts/test"
+	"github.com/Mist3rBru/go-clack/prompts/theme"
+	"github.com/Mist3rBru/go-clack/third_party/picocolors"
+)
+
+type DateParams struct {
+	Name         string
+	Message      string
+	InitialValue time.Time
+	Layout       string
+	Min          time.Time
+	Max          time.Time
+	IsDisabled   func(date time.Time) bool
+	WithTime     bool
+	WeekStart    time.Weekday
+	Validate     func(value time.Time) error
+}
+
+func Date(params DateParams) (time.Time, error) {
+	return DateContext(context.Background(), params)
+}
+
+func DateContext(ctx context.Context, params DateParams) (time.Time, error) {
+	p := core.NewDatePrompt(core.DatePromptParams{
+		InitialValue: params.InitialValue,
+		Layout:       params.Layout,
+		Min:          params.Min,
+		Max:          params.Max,
+		IsDisabled:   params.IsDisabled,
+		WithTime:     params.WithTime,
+		WeekStart:    params.WeekStart,
+		Validate:     params.Validate,
+		Render: func(p *core.DatePrompt) string {
+			valueWithCursor := p.ValueWithCursor()
+
+			switch p.State {
+			case core.SubmitState, core.CancelState:
+			default:
+				calendar := p.Calendar(func(date time.Time, cell string) string {
+					switch {
+					case date.Day() == p.Value.Day():
+						return picocolors.Inverse(picocolors.Cyan(cell))
+					case !p.IsAvailable(date):
+						return picocolors.Dim(picocolors.Strikethrough(cell))
+					}
+					return cell
+				}, core.FormatLinesOptions{})
+				valueWithCursor += "\n" + strings.ReplaceAll(calendar, "\r\n", "\n")
+				if p.WithTime {
+					valueWithCursor += "\n" + picocolors.Dim("Time:") + " " + p.TimeWithCursor() + " " + picocolors.Dim("(Tab)")
+				}
+			}
+
+			return theme.ApplyTheme(theme.ThemeParams[time.Time]{
+				Ctx:             p.Prompt,
+				Message:         params.Message,
+				Value:           p.FormattedValue(),
+				ValueWithCursor: valueWithCursor,
+			})
+		},
+	})
+	test.DateTestingPrompt = p
+	return runPrompt(ctx, &p.Prompt, params.Name)
+}
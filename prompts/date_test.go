@@ -0,0 +1,68 @@
+package prompts_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mist3rBru/go-clack/core"
+	"github.com/Mist3rBru/go-clack/prompts"
+	"github.com/Mist3rBru/go-clack/prompts/symbols"
+	"github.com/Mist3rBru/go-clack/prompts/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func runDate(t *testing.T, params prompts.DateParams) (*core.DatePrompt, <-chan time.Time) {
+	test.DateTestingPrompt = nil
+	result := make(chan time.Time, 1)
+	go func() {
+		value, _ := prompts.Date(params)
+		result <- value
+	}()
+
+	assert.Eventually(t, func() bool { return test.DateTestingPrompt != nil }, time.Second, time.Millisecond)
+	return test.DateTestingPrompt, result
+}
+
+func TestDateInitialState(t *testing.T) {
+	p, _ := runDate(t, prompts.DateParams{
+		Message:      message,
+		InitialValue: time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC),
+	})
+
+	expected := strings.Join([]string{
+		symbols.BAR,
+		symbols.State(core.InitialState) + " " + message,
+		symbols.BAR + " 2024-05-15 ",
+		symbols.BAR + " May 2024",
+		symbols.BAR + " Su Mo Tu We Th Fr Sa",
+		symbols.BAR + "           1  2  3  4",
+		symbols.BAR + "  5  6  7  8  9 10 11",
+		symbols.BAR + " 12 13 14 15 16 17 18",
+		symbols.BAR + " 19 20 21 22 23 24 25",
+		symbols.BAR + " 26 27 28 29 30 31",
+		symbols.BAR_END,
+	}, "\r\n")
+	assert.Equal(t, core.InitialState, p.State)
+	assert.Equal(t, expected, p.Frame)
+}
+
+func TestDateSubmitState(t *testing.T) {
+	p, result := runDate(t, prompts.DateParams{
+		Message:      message,
+		InitialValue: time.Date(2024, 5, 15, 9, 0, 0, 0, time.UTC),
+		WithTime:     true,
+	})
+
+	p.PressKey(&core.Key{Name: core.DownKey})
+	p.PressKey(&core.Key{Name: core.TabKey})
+	p.PressKey(&core.Key{Name: core.UpKey})
+	p.PressKey(&core.Key{Name: core.EnterKey})
+
+	title := symbols.State(core.SubmitState) + " " + message
+	value := symbols.BAR + " 2024-05-22 10:00"
+	expected := strings.Join([]string{symbols.BAR, title, value}, "\r\n")
+	assert.Equal(t, core.SubmitState, p.State)
+	assert.Equal(t, expected, p.Frame)
+	assert.Equal(t, time.Date(2024, 5, 22, 10, 0, 0, 0, time.UTC), <-result)
+}
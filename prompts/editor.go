@@ -0,0 +1,73 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Mist3rBru/go-clack/core"
+	"github.com/Mist3rBru/go-clack/core/utils"
+	"github.com/Mist3rBru/go-clack/prompts/test"
+	"github.com/Mist3rBru/go-clack/prompts/theme"
+	"github.com/Mist3rBru/go-clack/third_party/picocolors"
+)
+
+type EditorParams struct {
+	Name         string
+	Message      string
+	InitialValue string
+	// Editor is the command that edits the file, defaulting to $VISUAL, then $EDITOR
+	Editor        string
+	Extension     string
+	CommentPrefix string
+	Required      bool
+	Validate      func(value string) error
+}
+
+func Editor(params EditorParams) (string, error) {
+	return EditorContext(context.Background(), params)
+}
+
+func EditorContext(ctx context.Context, params EditorParams) (string, error) {
+	p := core.NewEditorPrompt(core.EditorPromptParams{
+		InitialValue:  params.InitialValue,
+		Editor:        params.Editor,
+		Extension:     params.Extension,
+		CommentPrefix: params.CommentPrefix,
+		Required:      params.Required,
+		Validate:      params.Validate,
+		Render: func(p *core.EditorPrompt) string {
+			editor := filepath.Base(strings.Fields(p.Editor + " ")[0])
+			hint := picocolors.Dim("Press Enter to open " + editor)
+
+			valueWithCursor := hint
+			if p.Value != "" {
+				valueWithCursor = editorSummary(p.Value) + "\n" + hint
+			}
+
+			return theme.ApplyTheme(theme.ThemeParams[string]{
+				Ctx:             p.Prompt,
+				Message:         params.Message,
+				Value:           editorSummary(p.Value),
+				ValueWithCursor: valueWithCursor,
+			})
+		},
+	})
+	test.EditorTestingPrompt = p
+	return runPrompt(ctx, &p.Prompt, params.Name)
+}
+
+// editorSummary summarizes the edited content by its first line and the number of lines left.
+func editorSummary(value string) string {
+	lines := strings.Split(value, "\n")
+	summary := lines[0]
+	if utils.StrLength(summary) > 60 {
+		head, _ := utils.SplitWidth(summary, 59)
+		summary = head + "…"
+	}
+	if len(lines) > 1 {
+		summary += picocolors.Dim(fmt.Sprintf(" (+%d lines)", len(lines)-1))
+	}
+	return summary
+}
@@ -0,0 +1,61 @@
+package prompts_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mist3rBru/go-clack/core"
+	"github.com/Mist3rBru/go-clack/prompts"
+	"github.com/Mist3rBru/go-clack/prompts/symbols"
+	"github.com/Mist3rBru/go-clack/prompts/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func runEditor(t *testing.T, params prompts.EditorParams) (*core.EditorPrompt, <-chan string) {
+	test.EditorTestingPrompt = nil
+	result := make(chan string, 1)
+	go func() {
+		value, _ := prompts.Editor(params)
+		result <- value
+	}()
+
+	assert.Eventually(t, func() bool { return test.EditorTestingPrompt != nil }, time.Second, time.Millisecond)
+	return test.EditorTestingPrompt, result
+}
+
+func TestEditorInitialState(t *testing.T) {
+	p, _ := runEditor(t, prompts.EditorParams{Message: message, Editor: "code --wait"})
+
+	expected := strings.Join([]string{
+		symbols.BAR,
+		symbols.State(core.InitialState) + " " + message,
+		symbols.BAR + " Press Enter to open code",
+		symbols.BAR_END,
+	}, "\r\n")
+	assert.Equal(t, core.InitialState, p.State)
+	assert.Equal(t, expected, p.Frame)
+}
+
+func TestEditorSubmitState(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake editor is a shell script")
+	}
+	editor := filepath.Join(t.TempDir(), "editor")
+	assert.NoError(t, os.WriteFile(editor, []byte("#!/bin/sh\nprintf 'foo\\nbar\\nbaz\\n# comment\\n' > \"$1\"\n"), 0o755))
+
+	p, result := runEditor(t, prompts.EditorParams{Message: message, Editor: editor})
+
+	p.PressKey(&core.Key{Name: core.EnterKey})
+
+	expected := strings.Join([]string{
+		symbols.BAR,
+		symbols.State(core.SubmitState) + " " + message,
+		symbols.BAR + " foo (+2 lines)",
+	}, "\r\n")
+	assert.Equal(t, expected, p.Frame)
+	assert.Equal(t, "foo\nbar\nbaz", <-result)
+}
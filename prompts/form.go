@@ -0,0 +1,388 @@
+package prompts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type formTag struct {
+	message     string
+	placeholder string
+	options     []string
+	kind        string
+	layout      string
+	required    bool
+	filter      bool
+	onlyDir     bool
+	skip        bool
+}
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+// Form builds a workflow that prompts for each exported field of the struct pointed to by v,
+// described by its `clack` tag, e.g. `clack:"message=Project name,placeholder=my-app,required"`.
+//
+// Supported tag keys are `message`, `placeholder`, `required`, `options` (separated by `|`),
+// `filter`, `dir`, `layout` (for time values) and `type`, one of `text`, `password`, `confirm`,
+// `select`, `multiselect` or `path`. A `clack:"-"` tag skips the field.
+// Values can contain commas, like `message=Hello, world,required`, as the tag is only split before a key.
+//
+// Booleans are prompted with Confirm, fields with options with Select, or MultiSelect for slices,
+// and other fields with Text, parsing ints, floats, durations and times into the field type.
+// Nested structs are prompted as grouped sections, titled by their message.
+// The current field values are used as initial values.
+func Form(v any) *WorkflowBuilder {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("form error: expected a pointer to a struct, got `%T`", v))
+	}
+
+	w := Workflow(v)
+	sv := rv.Elem()
+	for i := range sv.NumField() {
+		field := sv.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := parseFormTag(field)
+		if tag.skip {
+			continue
+		}
+
+		value := sv.Field(i)
+		if value.Kind() == reflect.Struct && value.Type() != timeType {
+			w.LogStep(field.Name, func() {
+				Step(tag.message)
+			})
+			w.ForkStep(field.Name, func() bool { return true }, func() *WorkflowBuilder {
+				section := Form(value.Addr().Interface())
+				if w.answers != nil {
+					section.answers = sectionAnswers{answers: w.answers, section: field.Name}
+				}
+				return section
+			})
+			continue
+		}
+
+		w.StepContext(field.Name, formPrompt(field, value, tag))
+	}
+
+	return w
+}
+
+func parseFormTag(field reflect.StructField) formTag {
+	tag := formTag{message: field.Name, layout: time.DateOnly}
+
+	value := field.Tag.Get("clack")
+	if value == "-" {
+		tag.skip = true
+		return tag
+	}
+
+	for _, part := range splitFormTag(value) {
+		key, val, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "message":
+			tag.message = val
+		case "placeholder":
+			tag.placeholder = val
+		case "options":
+			tag.options = strings.Split(val, "|")
+		case "type":
+			tag.kind = val
+		case "layout":
+			tag.layout = val
+		case "required":
+			tag.required = true
+		case "filter":
+			tag.filter = true
+		case "dir":
+			tag.onlyDir = true
+		}
+	}
+
+	if tag.kind == "" {
+		switch {
+		case field.Type.Kind() == reflect.Bool:
+			tag.kind = "confirm"
+		case len(tag.options) > 0 && field.Type.Kind() == reflect.Slice:
+			tag.kind = "multiselect"
+		case len(tag.options) > 0:
+			tag.kind = "select"
+		default:
+			tag.kind = "text"
+		}
+	}
+
+	return tag
+}
+
+// formTagKeys are the keys of the `clack` tag.
+var formTagKeys = map[string]bool{
+	"message": true, "placeholder": true, "options": true, "type": true,
+	"layout": true, "required": true, "filter": true, "dir": true,
+}
+
+// splitFormTag splits the tag on the commas followed by a known key,
+// so values like messages and layouts can contain commas.
+func splitFormTag(value string) []string {
+	var parts []string
+	for _, part := range strings.Split(value, ",") {
+		key, _, _ := strings.Cut(strings.TrimSpace(part), "=")
+		if formTagKeys[key] || len(parts) == 0 {
+			parts = append(parts, part)
+		} else {
+			parts[len(parts)-1] += "," + part
+		}
+	}
+	return parts
+}
+
+// formPrompt returns the step prompt of a field, converting its result into the field type.
+func formPrompt(field reflect.StructField, value reflect.Value, tag formTag) func(ctx context.Context) (any, error) {
+	t := field.Type
+	if !isFormType(t) {
+		panic(fmt.Sprintf("form error: unsupported type `%s` of field `%s`", t, field.Name))
+	}
+
+	parse := func(str string) (any, error) {
+		if str == "" {
+			return reflect.Zero(t).Interface(), nil
+		}
+		result, err := parseFormValue(str, t, tag.layout)
+		if err != nil {
+			return nil, err
+		}
+		return result.Interface(), nil
+	}
+
+	switch tag.kind {
+	case "confirm":
+		return func(ctx context.Context) (any, error) {
+			result, err := ConfirmContext(ctx, ConfirmParams{
+				Message:      tag.message,
+				InitialValue: value.Kind() == reflect.Bool && value.Bool(),
+			})
+			if err != nil {
+				return nil, err
+			}
+			return parse(strconv.FormatBool(result))
+		}
+
+	case "select":
+		return func(ctx context.Context) (any, error) {
+			options := make([]*SelectOption[string], len(tag.options))
+			for i, option := range tag.options {
+				options[i] = &SelectOption[string]{Label: option, Value: option}
+			}
+			result, err := SelectContext(ctx, SelectParams[string]{
+				Message:      tag.message,
+				InitialValue: formatFormValue(value, tag.layout),
+				Options:      options,
+				Filter:       tag.filter,
+				Required:     tag.required,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return parse(result)
+		}
+
+	case "multiselect":
+		return func(ctx context.Context) (any, error) {
+			var initialValue []string
+			if value.Kind() == reflect.Slice {
+				for i := range value.Len() {
+					initialValue = append(initialValue, formatFormValue(value.Index(i), tag.layout))
+				}
+			}
+			options := make([]*MultiSelectOption[string], len(tag.options))
+			for i, option := range tag.options {
+				options[i] = &MultiSelectOption[string]{Label: option, Value: option}
+			}
+			result, err := MultiSelectContext(ctx, MultiSelectParams[string]{
+				Message:      tag.message,
+				InitialValue: initialValue,
+				Options:      options,
+				Filter:       tag.filter,
+				Required:     tag.required,
+			})
+			if err != nil {
+				return nil, err
+			}
+			if t.Kind() != reflect.Slice || len(result) == 0 {
+				return parse(strings.Join(result, ","))
+			}
+			// The options are parsed one by one, as they may contain commas
+			items := reflect.MakeSlice(t, 0, len(result))
+			for _, option := range result {
+				item, err := parseFormValue(option, t.Elem(), tag.layout)
+				if err != nil {
+					return nil, err
+				}
+				items = reflect.Append(items, item)
+			}
+			return items.Interface(), nil
+		}
+	}
+
+	return func(ctx context.Context) (any, error) {
+		initialValue := formatFormValue(value, tag.layout)
+		validate := func(str string) error {
+			if str == "" {
+				return nil
+			}
+			_, err := parseFormValue(str, t, tag.layout)
+			return err
+		}
+
+		var result string
+		var err error
+		switch tag.kind {
+		case "password":
+			result, err = PasswordContext(ctx, PasswordParams{
+				Message:      tag.message,
+				InitialValue: initialValue,
+				Required:     tag.required,
+				Validate:     validate,
+			})
+		case "path":
+			result, err = PathContext(ctx, PathParams{
+				Message:      tag.message,
+				InitialValue: initialValue,
+				OnlyShowDir:  tag.onlyDir,
+				Required:     tag.required,
+				Validate:     validate,
+			})
+		default:
+			result, err = TextContext(ctx, TextParams{
+				Message:      tag.message,
+				Placeholder:  tag.placeholder,
+				InitialValue: initialValue,
+				Required:     tag.required,
+				Validate:     validate,
+			})
+		}
+		if err != nil {
+			return nil, err
+		}
+		return parse(result)
+	}
+}
+
+func isFormType(t reflect.Type) bool {
+	if t == durationType || t == timeType {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Slice:
+		return t.Elem().Kind() != reflect.Slice && isFormType(t.Elem())
+	}
+	return false
+}
+
+// parseFormValue parses a string into a value of the given type.
+// Slices are parsed from comma-separated items, and times also accept RFC 3339.
+func parseFormValue(str string, t reflect.Type, layout string) (reflect.Value, error) {
+	switch t {
+	case durationType:
+		d, err := time.ParseDuration(str)
+		if err != nil {
+			return reflect.Value{}, errors.New("Please enter a valid duration, like 1h30m.")
+		}
+		return reflect.ValueOf(d), nil
+	case timeType:
+		tm, err := time.Parse(layout, str)
+		if err != nil {
+			if tm, err = time.Parse(time.RFC3339, str); err != nil {
+				return reflect.Value{}, fmt.Errorf("Please enter a valid time, like %s.", layout)
+			}
+		}
+		return reflect.ValueOf(tm), nil
+	}
+
+	v := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.String:
+		v.SetString(str)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(str)
+		if err != nil {
+			return reflect.Value{}, errors.New("Please enter true or false.")
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(str, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, errors.New("Please enter a valid integer.")
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(str, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, errors.New("Please enter a valid positive integer.")
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(str, t.Bits())
+		if err != nil {
+			return reflect.Value{}, errors.New("Please enter a valid number.")
+		}
+		v.SetFloat(n)
+	case reflect.Slice:
+		for _, item := range strings.Split(str, ",") {
+			elem, err := parseFormValue(strings.TrimSpace(item), t.Elem(), layout)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			v = reflect.Append(v, elem)
+		}
+	}
+	return v, nil
+}
+
+// formatFormValue formats a field value as the initial value of its prompt, leaving zero values empty.
+func formatFormValue(v reflect.Value, layout string) string {
+	if v.IsZero() {
+		return ""
+	}
+	switch v.Type() {
+	case durationType:
+		return v.Interface().(time.Duration).String()
+	case timeType:
+		return v.Interface().(time.Time).Format(layout)
+	}
+	if v.Kind() == reflect.Slice {
+		items := make([]string, v.Len())
+		for i := range v.Len() {
+			items[i] = formatFormValue(v.Index(i), layout)
+		}
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// sectionAnswers looks up the answers of a form section prefixed by the section name, like `Database.Host`,
+// which map answers also resolve from nested maps.
+type sectionAnswers struct {
+	answers Answers
+	section string
+}
+
+func (a sectionAnswers) Lookup(name string) (any, bool) {
+	return a.answers.Lookup(a.section + "." + name)
+}
@@ -0,0 +1,116 @@
+package prompts_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Mist3rBru/go-clack/core"
+	"github.com/Mist3rBru/go-clack/prompts"
+	"github.com/stretchr/testify/assert"
+)
+
+type formDatabase struct {
+	Host string `clack:"message=Database host"`
+	Port int    `clack:"message=Database port"`
+}
+
+type formConfig struct {
+	Name     string        `clack:"message=Project name,placeholder=my-app,required"`
+	Secret   string        `clack:"message=Secret,type=password"`
+	Port     uint16        `clack:"message=Port"`
+	Ratio    float64       `clack:"message=Ratio"`
+	Timeout  time.Duration `clack:"message=Timeout"`
+	Start    time.Time     `clack:"message=Start date,layout=2006-01-02"`
+	Install  bool          `clack:"message=Install dependencies?"`
+	Manager  string        `clack:"message=Package manager,options=npm|yarn|pnpm"`
+	Tools    []string      `clack:"message=Tools,options=eslint|prettier|jest"`
+	Database formDatabase  `clack:"message=Database"`
+	Ignored  string        `clack:"-"`
+	internal string
+}
+
+func TestFormWithAnswers(t *testing.T) {
+	var config formConfig
+	config.Ignored = "ignored"
+
+	err := prompts.Form(&config).
+		Answers(prompts.MapAnswers{
+			"name":    "my-app",
+			"secret":  "shh",
+			"port":    8080.0,
+			"ratio":   "0.5",
+			"timeout": "1m30s",
+			"start":   "2024-05-01",
+			"install": true,
+			"manager": "yarn",
+			"tools":   []any{"eslint", "jest"},
+			"database": map[string]any{
+				"host": "localhost",
+				"port": "5432",
+			},
+		}).
+		Run()
+
+	assert.NoError(t, err)
+	assert.Equal(t, formConfig{
+		Name:     "my-app",
+		Secret:   "shh",
+		Port:     8080,
+		Ratio:    0.5,
+		Timeout:  90 * time.Second,
+		Start:    time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		Install:  true,
+		Manager:  "yarn",
+		Tools:    []string{"eslint", "jest"},
+		Database: formDatabase{Host: "localhost", Port: 5432},
+		Ignored:  "ignored",
+	}, config)
+}
+
+func TestFormTagWithCommas(t *testing.T) {
+	var config struct {
+		Start time.Time `clack:"message=Start date, like Jan 2, 2006,layout=Jan 2, 2006,required"`
+	}
+
+	err := prompts.Form(&config).
+		Answers(prompts.MapAnswers{"start": "May 1, 2024"}).
+		Run()
+
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), config.Start)
+}
+
+func TestFormWithInvalidAnswer(t *testing.T) {
+	var config struct {
+		Port int `clack:"message=Port"`
+	}
+
+	err := prompts.Form(&config).
+		Answers(prompts.MapAnswers{"port": "eighty"}).
+		Run()
+
+	assert.ErrorIs(t, err, core.ErrInvalidAnswer)
+	assert.ErrorContains(t, err, "Please enter a valid integer.")
+}
+
+func TestFormWithUnsupportedType(t *testing.T) {
+	var config struct {
+		Values map[string]string
+	}
+
+	assert.Panics(t, func() { prompts.Form(&config) })
+	assert.Panics(t, func() { prompts.Form(config) })
+}
+
+func TestFormMultiSelectWithTypedOptions(t *testing.T) {
+	var config struct {
+		Sizes []int `clack:"message=Sizes,options=1|2|3"`
+	}
+
+	err := prompts.Form(&config).
+		Answers(prompts.MapAnswers{"sizes": []any{1, 3}}).
+		Run()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 3}, config.Sizes)
+}
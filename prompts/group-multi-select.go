@@ -1,6 +1,10 @@
 package prompts
 
 import (
+	"context"
+	"fmt"
+	"strings"
+
 	"github.com/Mist3rBru/go-clack/core"
 	"github.com/Mist3rBru/go-clack/core/validator"
 	"github.com/Mist3rBru/go-clack/prompts/symbols"
@@ -9,10 +13,25 @@ import (
 	"github.com/Mist3rBru/go-clack/third_party/picocolors"
 )
 
+// MultiSelectGroup is a named group of options, listed in order by the GroupMultiSelect.
+type MultiSelectGroup[TValue comparable] struct {
+	Label   string
+	Options []MultiSelectOption[TValue]
+	// Groups are nested in the group, listed after its options
+	Groups []MultiSelectGroup[TValue]
+}
+
 type GroupMultiSelectParams[TValue comparable] struct {
-	Message        string
-	Options        map[string][]MultiSelectOption[TValue]
-	InitialValue   []TValue
+	Name    string
+	Message string
+	// Groups are listed in order, before the Options
+	Groups []MultiSelectGroup[TValue]
+	// Options are grouped by name, and listed in the groups' alphabetical order
+	Options      map[string][]MultiSelectOption[TValue]
+	InitialValue []TValue
+	Filter       bool
+	// RegexFilter matches the search as a regular expression, instead of fuzzily
+	RegexFilter    bool
 	DisabledGroups bool
 	SpacedGroups   bool
 	Required       bool
@@ -20,85 +39,143 @@ type GroupMultiSelectParams[TValue comparable] struct {
 }
 
 func GroupMultiSelect[TValue comparable](params GroupMultiSelectParams[TValue]) ([]TValue, error) {
+	return GroupMultiSelectContext(context.Background(), params)
+}
+
+func GroupMultiSelectContext[TValue comparable](ctx context.Context, params GroupMultiSelectParams[TValue]) ([]TValue, error) {
 	v := validator.NewValidator("GroupMultiSelect")
-	v.ValidateOptions(len(params.Options))
+	v.ValidateOptions(len(params.Groups) + len(params.Options))
 
-	groups := make(map[string][]core.MultiSelectOption[TValue])
+	groups := mapGroups(params.Groups)
+	groupOptions := make(map[string][]core.MultiSelectOption[TValue])
 	for group, options := range params.Options {
-		groups[group] = make([]core.MultiSelectOption[TValue], len(options))
-		for i, option := range options {
-			groups[group][i] = core.MultiSelectOption[TValue]{
-				Label:      option.Label,
-				Value:      option.Value,
-				IsSelected: option.IsSelected,
-			}
-		}
+		groupOptions[group] = mapGroupOptions(options)
 	}
 
 	p := core.NewGroupMultiSelectPrompt(core.GroupMultiSelectPromptParams[TValue]{
 		InitialValue:   params.InitialValue,
-		Options:        groups,
+		Groups:         groups,
+		Options:        groupOptions,
+		Filter:         params.Filter,
+		RegexFilter:    params.RegexFilter,
 		DisabledGroups: params.DisabledGroups,
 		Required:       params.Required,
 		Validate:       params.Validate,
 		Render: func(p *core.GroupMultiSelectPrompt[TValue]) string {
+			message := params.Message
 			var value string
 
 			switch p.State {
 			case core.SubmitState, core.CancelState:
-				for _, option := range p.Options {
-					if option.IsSelected {
-						if value == "" {
-							value = option.Label
-						} else {
-							value += ", " + option.Label
-						}
-					}
+				labels := []string{}
+				for _, option := range p.SelectedOptions() {
+					labels = append(labels, option.Label)
 				}
+				value = strings.Join(labels, ", ")
 
 			default:
 				radioOptions := make([]string, len(p.Options))
 				for i, option := range p.Options {
+					indent := strings.Repeat(" ", option.Depth)
 					if option.IsGroup {
-						radioOptions[i] = groupOption(option, p.IsGroupSelected(option), i == p.CursorIndex, p.DisabledGroups)
-						if params.SpacedGroups && i > 0 {
+						radioOptions[i] = indent + groupOption(option, p.IsGroupSelected(option), p.IsGroupIndeterminate(option), i == p.CursorIndex, p.DisabledGroups)
+						if !option.IsOpen && p.Search == "" {
+							radioOptions[i] += " " + picocolors.Dim(fmt.Sprintf("(+%d)", countGroupOptions(option)))
+						}
+						if params.SpacedGroups && i > 0 && option.Depth == 0 {
 							radioOptions[i] = "\n" + radioOptions[i]
 						}
 						continue
 					}
 
-					radioOptions[i] = " " + groupOption(option, option.IsSelected, i == p.CursorIndex, false)
+					radioOptions[i] = indent + groupOption(option, option.IsSelected, false, i == p.CursorIndex, false)
+				}
+
+				if p.Filter {
+					if p.Search == "" {
+						message = fmt.Sprintf("%s\n> %s", message, picocolors.Inverse("T")+picocolors.Dim("ype to filter..."))
+					} else {
+						message = fmt.Sprintf("%s\n> %s", message, p.Search+picocolors.Inverse(" "))
+					}
+
+					value = p.LimitLines(radioOptions, 4)
+					break
 				}
+
 				value = p.LimitLines(radioOptions, 3)
 			}
 
 			return theme.ApplyTheme(theme.ThemeParams[[]TValue]{
 				Ctx:             p.Prompt,
-				Message:         params.Message,
+				Message:         message,
 				Value:           value,
 				ValueWithCursor: value,
 			})
 		},
 	})
 	test.GroupMultiSelectTestingPrompt = p
-	return p.Run()
+	return runPrompt(ctx, &p.Prompt, params.Name)
+}
+
+func mapGroups[TValue comparable](groups []MultiSelectGroup[TValue]) []core.MultiSelectGroup[TValue] {
+	var coreGroups []core.MultiSelectGroup[TValue]
+	for _, group := range groups {
+		coreGroups = append(coreGroups, core.MultiSelectGroup[TValue]{
+			Label:   group.Label,
+			Options: mapGroupOptions(group.Options),
+			Groups:  mapGroups(group.Groups),
+		})
+	}
+	return coreGroups
+}
+
+// countGroupOptions counts the options nested in the group, at any depth, leaving out the groups.
+func countGroupOptions[TValue comparable](group *core.GroupMultiSelectOption[TValue]) int {
+	count := 0
+	for _, option := range group.Options {
+		if option.IsGroup {
+			count += countGroupOptions(option)
+			continue
+		}
+		count++
+	}
+	return count
+}
+
+func mapGroupOptions[TValue comparable](options []MultiSelectOption[TValue]) []core.MultiSelectOption[TValue] {
+	groupOptions := make([]core.MultiSelectOption[TValue], len(options))
+	for i, option := range options {
+		groupOptions[i] = core.MultiSelectOption[TValue]{
+			Label:      option.Label,
+			Value:      option.Value,
+			IsSelected: option.IsSelected,
+		}
+	}
+	return groupOptions
 }
 
-func groupOption[TValue comparable](option *core.GroupMultiSelectOption[TValue], isSelected, isActive, isDisabled bool) string {
+func groupOption[TValue comparable](option *core.GroupMultiSelectOption[TValue], isSelected, isPartial, isActive, isDisabled bool) string {
 	var radio, label string
+	optionLabel := core.HighlightMatches(option.Label, option.Matches, picocolors.Underline)
 
 	if isSelected && isActive {
 		radio = picocolors.Green(symbols.CHECKBOX_SELECTED)
-		label = option.Label
+		label = optionLabel
+	} else if isPartial && isActive {
+		radio = picocolors.Green(symbols.CHECKBOX_PARTIAL)
+		label = optionLabel
 	} else if isActive {
 		radio = picocolors.Green(symbols.CHECKBOX_ACTIVE)
-		label = option.Label
+		label = optionLabel
 	} else if isSelected {
 		radio = picocolors.Green(symbols.CHECKBOX_SELECTED)
-		label = picocolors.Dim(option.Label)
+		label = picocolors.Dim(optionLabel)
+	} else if isPartial {
+		radio = picocolors.Green(symbols.CHECKBOX_PARTIAL)
+		label = picocolors.Dim(optionLabel)
 	} else {
 		radio = picocolors.Dim(symbols.CHECKBOX_INACTIVE)
-		label = picocolors.Dim(option.Label)
+		label = picocolors.Dim(optionLabel)
 	}
 
 	if isDisabled {
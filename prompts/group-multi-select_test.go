@@ -172,3 +172,88 @@ func TestGroupMultiSelectDisabledAndSpacedGroups(t *testing.T) {
 	p.PressKey(&core.Key{Name: core.SpaceKey})
 	cupaloy.SnapshotT(t, p.Frame)
 }
+
+func TestGroupMultiSelectOrderedGroups(t *testing.T) {
+	go prompts.GroupMultiSelect(prompts.GroupMultiSelectParams[string]{
+		Message: message,
+		Groups: []prompts.MultiSelectGroup[string]{
+			{Label: "2", Options: []prompts.MultiSelectOption[string]{{Label: "x"}}},
+			{Label: "1", Options: []prompts.MultiSelectOption[string]{{Label: "a"}}},
+		},
+	})
+	time.Sleep(time.Millisecond)
+	p := test.GroupMultiSelectTestingPrompt.(*core.GroupMultiSelectPrompt[string])
+
+	assert.Equal(t, core.InitialState, p.State)
+	cupaloy.SnapshotT(t, p.Frame)
+}
+
+func runNestedGroupMultiSelect() {
+	prompts.GroupMultiSelect(prompts.GroupMultiSelectParams[string]{
+		Message: message,
+		Filter:  true,
+		Groups: []prompts.MultiSelectGroup[string]{
+			{
+				Label: "web",
+				Groups: []prompts.MultiSelectGroup[string]{
+					{Label: "auth", Options: []prompts.MultiSelectOption[string]{{Label: "login"}, {Label: "signup"}}},
+					{Label: "billing", Options: []prompts.MultiSelectOption[string]{{Label: "invoices"}}},
+				},
+			},
+		},
+	})
+}
+
+func TestGroupMultiSelectNestedGroups(t *testing.T) {
+	go runNestedGroupMultiSelect()
+	time.Sleep(time.Millisecond)
+	p := test.GroupMultiSelectTestingPrompt.(*core.GroupMultiSelectPrompt[string])
+
+	p.PressKey(&core.Key{Name: core.DownKey})
+	p.PressKey(&core.Key{Name: core.DownKey})
+	p.PressKey(&core.Key{Name: core.SpaceKey})
+
+	assert.Equal(t, []string{"login"}, p.Value)
+	cupaloy.SnapshotT(t, p.Frame)
+}
+
+func TestGroupMultiSelectCollapsedGroups(t *testing.T) {
+	go runNestedGroupMultiSelect()
+	time.Sleep(time.Millisecond)
+	p := test.GroupMultiSelectTestingPrompt.(*core.GroupMultiSelectPrompt[string])
+
+	p.PressKey(&core.Key{Name: core.DownKey})
+	p.PressKey(&core.Key{Name: core.LeftKey})
+
+	assert.Equal(t, 4, len(p.Options))
+	cupaloy.SnapshotT(t, p.Frame)
+}
+
+func TestGroupMultiSelectFilter(t *testing.T) {
+	go runNestedGroupMultiSelect()
+	time.Sleep(time.Millisecond)
+	p := test.GroupMultiSelectTestingPrompt.(*core.GroupMultiSelectPrompt[string])
+
+	p.PressKey(&core.Key{Char: "s"})
+	p.PressKey(&core.Key{Char: "i"})
+	p.PressKey(&core.Key{Char: "g"})
+
+	assert.Equal(t, 3, len(p.Options))
+	cupaloy.SnapshotT(t, p.Frame)
+}
+
+func TestGroupMultiSelectSubmitCollapsedGroups(t *testing.T) {
+	go runNestedGroupMultiSelect()
+	time.Sleep(time.Millisecond)
+	p := test.GroupMultiSelectTestingPrompt.(*core.GroupMultiSelectPrompt[string])
+
+	p.PressKey(&core.Key{Name: core.DownKey})
+	p.PressKey(&core.Key{Name: core.DownKey})
+	p.PressKey(&core.Key{Name: core.SpaceKey})
+	p.PressKey(&core.Key{Name: core.UpKey})
+	p.PressKey(&core.Key{Name: core.LeftKey})
+	p.PressKey(&core.Key{Name: core.EnterKey})
+
+	assert.Equal(t, core.SubmitState, p.State)
+	assert.Contains(t, p.Frame, "login")
+}
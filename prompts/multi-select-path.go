@@ -1,6 +1,7 @@
 package prompts
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -12,6 +13,7 @@ import (
 )
 
 type MultiSelectPathParams struct {
+	Name         string
 	Message      string
 	InitialValue []string
 	InitialPath  string
@@ -19,10 +21,16 @@ type MultiSelectPathParams struct {
 	Validate     func(value []string) error
 	OnlyShowDir  bool
 	Filter       bool
-	FileSystem   FileSystem
+	// RegexFilter matches the search as a regular expression, instead of fuzzily
+	RegexFilter bool
+	FileSystem  FileSystem
 }
 
 func MultiSelectPath(params MultiSelectPathParams) ([]string, error) {
+	return MultiSelectPathContext(context.Background(), params)
+}
+
+func MultiSelectPathContext(ctx context.Context, params MultiSelectPathParams) ([]string, error) {
 	p := core.NewMultiSelectPathPrompt(core.MultiSelectPathPromptParams{
 		InitialValue: params.InitialValue,
 		InitialPath:  params.InitialPath,
@@ -30,6 +38,7 @@ func MultiSelectPath(params MultiSelectPathParams) ([]string, error) {
 		FileSystem:   params.FileSystem,
 		Required:     params.Required,
 		Filter:       params.Filter,
+		RegexFilter:  params.RegexFilter,
 		Validate:     params.Validate,
 		Render: func(p *core.MultiSelectPathPrompt) string {
 			message := params.Message
@@ -49,17 +58,17 @@ func MultiSelectPath(params MultiSelectPathParams) ([]string, error) {
 					}
 					if option.IsSelected && option.IsEqual(p.CurrentOption) {
 						radio = picocolors.Green(symbols.CHECKBOX_SELECTED)
-						label = option.Name
+						label = core.HighlightMatches(option.Name, option.Matches, picocolors.Underline)
 					} else if option.IsSelected {
 						radio = picocolors.Green(symbols.CHECKBOX_SELECTED)
-						label = picocolors.Dim(option.Name)
+						label = picocolors.Dim(core.HighlightMatches(option.Name, option.Matches, picocolors.Underline))
 						dir = picocolors.Dim(dir)
 					} else if option.IsEqual(p.CurrentOption) {
 						radio = picocolors.Green(symbols.CHECKBOX_ACTIVE)
-						label = option.Name
+						label = core.HighlightMatches(option.Name, option.Matches, picocolors.Underline)
 					} else {
 						radio = picocolors.Dim(symbols.CHECKBOX_INACTIVE)
-						label = picocolors.Dim(option.Name)
+						label = picocolors.Dim(core.HighlightMatches(option.Name, option.Matches, picocolors.Underline))
 						dir = picocolors.Dim(dir)
 					}
 					depth := strings.Repeat(" ", option.Depth)
@@ -89,5 +98,5 @@ func MultiSelectPath(params MultiSelectPathParams) ([]string, error) {
 		},
 	})
 	test.MultiSelectPathTestingPrompt = p
-	return p.Run()
+	return runPrompt(ctx, &p.Prompt, params.Name)
 }
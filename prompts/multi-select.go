@@ -1,6 +1,7 @@
 package prompts
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -16,36 +17,61 @@ type MultiSelectOption[TValue comparable] struct {
 	Label      string
 	Value      TValue
 	IsSelected bool
-	Hint       string
+	// Hint is shown for the option under the cursor, or always as the reason of a disabled option
+	Hint string
+	// IsDisabled shows the option without letting it be selected
+	IsDisabled bool
+	// IsSeparator shows the label as a heading between the options
+	IsSeparator bool
 }
 
 type MultiSelectParams[TValue comparable] struct {
+	Name         string
 	Message      string
 	Options      []*MultiSelectOption[TValue]
 	InitialValue []TValue
 	Filter       bool
-	Required     bool
-	Validate     func(value []TValue) error
+	// RegexFilter matches the search as a regular expression, instead of fuzzily
+	RegexFilter bool
+	Required    bool
+	// Min is the least number of selected options to submit, if not zero
+	Min int
+	// Max is the most number of selected options, if not zero
+	Max      int
+	Validate func(value []TValue) error
 }
 
 func MultiSelect[TValue comparable](params MultiSelectParams[TValue]) ([]TValue, error) {
+	return MultiSelectContext(context.Background(), params)
+}
+
+func MultiSelectContext[TValue comparable](ctx context.Context, params MultiSelectParams[TValue]) ([]TValue, error) {
 	v := validator.NewValidator("MultiSelect")
 	v.ValidateOptions(len(params.Options))
 
 	var options []*core.MultiSelectOption[TValue]
+	// The hints are looked up by option, as filtering reorders the options
+	hints := make(map[*core.MultiSelectOption[TValue]]string)
 	for _, option := range params.Options {
-		options = append(options, &core.MultiSelectOption[TValue]{
-			Label:      option.Label,
-			Value:      option.Value,
-			IsSelected: option.IsSelected,
-		})
+		coreOption := &core.MultiSelectOption[TValue]{
+			Label:       option.Label,
+			Value:       option.Value,
+			IsSelected:  option.IsSelected,
+			IsDisabled:  option.IsDisabled,
+			IsSeparator: option.IsSeparator,
+		}
+		hints[coreOption] = option.Hint
+		options = append(options, coreOption)
 	}
 
 	p := core.NewMultiSelectPrompt(core.MultiSelectPromptParams[TValue]{
 		InitialValue: params.InitialValue,
 		Options:      options,
 		Filter:       params.Filter,
+		RegexFilter:  params.RegexFilter,
 		Required:     params.Required,
+		Min:          params.Min,
+		Max:          params.Max,
 		Validate:     params.Validate,
 		Render: func(p *core.MultiSelectPrompt[TValue]) string {
 			message := params.Message
@@ -66,41 +92,57 @@ func MultiSelect[TValue comparable](params MultiSelectParams[TValue]) ([]TValue,
 			default:
 				radioOptions := make([]string, len(p.Options))
 				for i, option := range p.Options {
+					if option.IsSeparator {
+						radioOptions[i] = separatorLine(option.Label)
+						continue
+					}
+
 					var radio, label, hint string
-					if option.IsSelected && i == p.CursorIndex {
+					if option.IsDisabled {
+						radio = picocolors.Dim(symbols.CHECKBOX_INACTIVE)
+						label = picocolors.Dim(picocolors.Strikethrough(option.Label))
+						if hints[option] != "" {
+							hint = picocolors.Dim("(" + hints[option] + ")")
+						}
+					} else if option.IsSelected && i == p.CursorIndex {
 						radio = picocolors.Green(symbols.CHECKBOX_SELECTED)
-						label = option.Label
-						if params.Options[i].Hint != "" {
-							hint = picocolors.Dim("(" + params.Options[i].Hint + ")")
+						label = core.HighlightMatches(option.Label, option.Matches, picocolors.Underline)
+						if hints[option] != "" {
+							hint = picocolors.Dim("(" + hints[option] + ")")
 						}
 					} else if i == p.CursorIndex {
 						radio = picocolors.Green(symbols.CHECKBOX_ACTIVE)
-						label = option.Label
-						if params.Options[i].Hint != "" {
-							hint = picocolors.Dim("(" + params.Options[i].Hint + ")")
+						label = core.HighlightMatches(option.Label, option.Matches, picocolors.Underline)
+						if hints[option] != "" {
+							hint = picocolors.Dim("(" + hints[option] + ")")
 						}
 					} else if option.IsSelected {
 						radio = picocolors.Green(symbols.CHECKBOX_SELECTED)
-						label = picocolors.Dim(option.Label)
+						label = picocolors.Dim(core.HighlightMatches(option.Label, option.Matches, picocolors.Underline))
 					} else {
 						radio = picocolors.Dim(symbols.CHECKBOX_INACTIVE)
-						label = picocolors.Dim(option.Label)
+						label = picocolors.Dim(core.HighlightMatches(option.Label, option.Matches, picocolors.Underline))
 					}
 					radioOptions[i] = strings.Join([]string{radio, label, hint}, " ")
 				}
 
+				usedLines := 3
 				if p.Filter {
 					if p.Search == "" {
 						message = fmt.Sprintf("%s\n> %s", message, picocolors.Inverse("T")+picocolors.Dim("ype to filter..."))
 					} else {
 						message = fmt.Sprintf("%s\n> %s", message, p.Search+picocolors.Inverse(" "))
 					}
+					usedLines++
+				}
 
-					value = p.LimitLines(radioOptions, 4)
+				// The counter is shown for limited selections, and for long lists whose selected options may be out of view
+				if p.Min > 0 || p.Max > 0 || p.ExceedsLines(radioOptions, usedLines) {
+					value = p.LimitLines(radioOptions, usedLines+1) + "\n" + multiSelectCounter(len(p.Value), p.Min, p.Max)
 					break
 				}
 
-				value = p.LimitLines(radioOptions, 3)
+				value = p.LimitLines(radioOptions, usedLines)
 			}
 
 			return theme.ApplyTheme(theme.ThemeParams[[]TValue]{
@@ -112,5 +154,17 @@ func MultiSelect[TValue comparable](params MultiSelectParams[TValue]) ([]TValue,
 		},
 	})
 	test.MultiSelectTestingPrompt = p
-	return p.Run()
+	return runPrompt(ctx, &p.Prompt, params.Name)
+}
+
+// multiSelectCounter renders the number of selected options, out of the max, and the min if not reached yet.
+func multiSelectCounter(count, min, max int) string {
+	counter := fmt.Sprintf("%d selected", count)
+	if max > 0 {
+		counter = fmt.Sprintf("%d/%d selected", count, max)
+	}
+	if count < min {
+		counter += fmt.Sprintf(", at least %d", min)
+	}
+	return picocolors.Dim(counter)
 }
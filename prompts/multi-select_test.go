@@ -72,6 +72,24 @@ func TestMultiSelectWithSelectedHint(t *testing.T) {
 	cupaloy.SnapshotT(t, p.Frame)
 }
 
+func TestMultiSelectWithDisabledOptions(t *testing.T) {
+	go prompts.MultiSelect(prompts.MultiSelectParams[string]{
+		Message: message,
+		Options: []*prompts.MultiSelectOption[string]{
+			{Label: "Databases", IsSeparator: true},
+			{Label: "postgres", IsDisabled: true, Hint: "requires license"},
+			{Label: "mysql"},
+			{IsSeparator: true},
+			{Label: "redis", IsDisabled: true},
+		},
+	})
+	time.Sleep(time.Millisecond)
+	p := test.MultiSelectTestingPrompt.(*core.MultiSelectPrompt[string])
+
+	assert.Equal(t, 2, p.CursorIndex)
+	cupaloy.SnapshotT(t, p.Frame)
+}
+
 func TestMultiSelectCancelState(t *testing.T) {
 	go runMultiSelect()
 	time.Sleep(time.Millisecond)
@@ -119,6 +137,27 @@ func TestMultiSelectWithLongList(t *testing.T) {
 	cupaloy.SnapshotT(t, p.Frame)
 }
 
+func TestMultiSelectWithMax(t *testing.T) {
+	go prompts.MultiSelect(prompts.MultiSelectParams[string]{
+		Message: message,
+		Min:     1,
+		Max:     2,
+		Options: []*prompts.MultiSelectOption[string]{
+			{Label: "foo"},
+			{Label: "bar"},
+			{Label: "baz"},
+		},
+	})
+	time.Sleep(time.Millisecond)
+	p := test.MultiSelectTestingPrompt.(*core.MultiSelectPrompt[string])
+
+	assert.Contains(t, p.Frame, "0/2 selected, at least 1")
+	p.PressKey(&core.Key{Name: "a", Ctrl: true})
+	p.PressKey(&core.Key{Name: core.SpaceKey})
+	assert.Contains(t, p.Frame, "1/2 selected")
+	assert.NotContains(t, p.Frame, "at least")
+}
+
 func TestMultiSelectMultiValue(t *testing.T) {
 	go prompts.MultiSelect(prompts.MultiSelectParams[string]{
 		Message: message,
@@ -0,0 +1,83 @@
+package prompts
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Mist3rBru/go-clack/core"
+	"github.com/Mist3rBru/go-clack/core/utils"
+	"github.com/Mist3rBru/go-clack/prompts/test"
+	"github.com/Mist3rBru/go-clack/prompts/theme"
+	"github.com/Mist3rBru/go-clack/third_party/picocolors"
+)
+
+type MultilineParams struct {
+	Name         string
+	Message      string
+	Placeholder  string
+	InitialValue string
+	Required     bool
+	// SubmitKey is the key chord that submits the value, Ctrl+D by default
+	SubmitKey *core.Key
+	Validate  func(value string) error
+}
+
+// multilineFrameLines counts the lines of the frame around the value, for a one-line message:
+// the bar, the message, the submit hint and the end bar, plus a spare row so the frame never fills the terminal.
+const multilineFrameLines = 5
+
+func Multiline(params MultilineParams) (string, error) {
+	return MultilineContext(context.Background(), params)
+}
+
+func MultilineContext(ctx context.Context, params MultilineParams) (string, error) {
+	p := core.NewMultilinePrompt(core.MultilinePromptParams{
+		InitialValue: params.InitialValue,
+		Placeholder:  params.Placeholder,
+		Required:     params.Required,
+		SubmitKey:    params.SubmitKey,
+		Validate:     params.Validate,
+		Render: func(p *core.MultilinePrompt) string {
+			width, _, err := p.Size()
+			if err != nil {
+				width = 80
+			}
+
+			// The value is wrapped within the theme's bar, leaving room for the rest of the frame
+			usedLines := multilineFrameLines + strings.Count(params.Message, "\n")
+			valueWithCursor := p.ValueWithCursor(core.FormatLinesOptions{MaxWidth: width - 2}, usedLines)
+			if p.Value == "" && p.Placeholder != "" {
+				placeholder := utils.Graphemes(p.Placeholder)
+				valueWithCursor = picocolors.Inverse(placeholder[0]) + picocolors.Dim(strings.Join(placeholder[1:], ""))
+			}
+			valueWithCursor += "\n" + picocolors.Dim("Press "+formatKey(p.SubmitKey)+" to submit")
+
+			return theme.ApplyTheme(theme.ThemeParams[string]{
+				Ctx:             p.Prompt,
+				Message:         params.Message,
+				Value:           p.Value,
+				ValueWithCursor: valueWithCursor,
+			})
+		},
+	})
+	test.MultilineTestingPrompt = p
+	return runPrompt(ctx, &p.Prompt, params.Name)
+}
+
+// formatKey formats a key chord, like `Ctrl+D` or `Alt+Enter`.
+func formatKey(key *core.Key) string {
+	name := string(key.Name)
+	if len(name) == 1 {
+		name = strings.ToUpper(name)
+	}
+	if key.Shift {
+		name = "Shift+" + name
+	}
+	if key.Meta {
+		name = "Alt+" + name
+	}
+	if key.Ctrl {
+		name = "Ctrl+" + name
+	}
+	return name
+}
@@ -0,0 +1,69 @@
+package prompts_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mist3rBru/go-clack/core"
+	"github.com/Mist3rBru/go-clack/prompts"
+	"github.com/Mist3rBru/go-clack/prompts/symbols"
+	"github.com/Mist3rBru/go-clack/prompts/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func runMultiline(t *testing.T, params prompts.MultilineParams) (*core.MultilinePrompt, <-chan string) {
+	test.MultilineTestingPrompt = nil
+	result := make(chan string, 1)
+	go func() {
+		value, _ := prompts.Multiline(params)
+		result <- value
+	}()
+
+	assert.Eventually(t, func() bool { return test.MultilineTestingPrompt != nil }, time.Second, time.Millisecond)
+	return test.MultilineTestingPrompt, result
+}
+
+func TestMultilineInitialState(t *testing.T) {
+	p, _ := runMultiline(t, prompts.MultilineParams{Message: message, Placeholder: "foo"})
+
+	expected := strings.Join([]string{
+		symbols.BAR,
+		symbols.State(core.InitialState) + " " + message,
+		symbols.BAR + " foo",
+		symbols.BAR + " Press Ctrl+D to submit",
+		symbols.BAR_END,
+	}, "\r\n")
+	assert.Equal(t, core.InitialState, p.State)
+	assert.Equal(t, expected, p.Frame)
+}
+
+func TestMultilineSubmitState(t *testing.T) {
+	p, result := runMultiline(t, prompts.MultilineParams{
+		Message:   message,
+		SubmitKey: &core.Key{Name: core.EnterKey, Meta: true},
+	})
+
+	p.PressKey(&core.Key{Char: "a"})
+	p.PressKey(&core.Key{Name: core.EnterKey})
+	p.PressKey(&core.Key{Char: "b"})
+
+	activeValue := strings.Join([]string{
+		symbols.BAR + " a",
+		symbols.BAR + " b ",
+		symbols.BAR + " Press Alt+Enter to submit",
+	}, "\r\n")
+	assert.Contains(t, p.Frame, activeValue)
+
+	p.PressKey(&core.Key{Name: core.EnterKey, Meta: true})
+
+	expected := strings.Join([]string{
+		symbols.BAR,
+		symbols.State(core.SubmitState) + " " + message,
+		symbols.BAR + " a",
+		symbols.BAR + " b",
+	}, "\r\n")
+	assert.Equal(t, core.SubmitState, p.State)
+	assert.Equal(t, expected, p.Frame)
+	assert.Equal(t, "a\nb", <-result)
+}
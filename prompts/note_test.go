@@ -40,6 +40,22 @@ func TestNoteBoxMultiline(t *testing.T) {
 	}, "\r\n"), writer.Data[0])
 }
 
+func TestNoteBoxWideCharacters(t *testing.T) {
+	writer := &MockWriter{}
+	prompts.Note("日本\nçã", prompts.NoteOptions{Output: writer})
+
+	assert.Equal(t, strings.Join([]string{
+		"│",
+		"├────────╮",
+		"│        │",
+		"│  日本  │",
+		"│  çã    │",
+		"│        │",
+		"├────────╯",
+		"",
+	}, "\r\n"), writer.Data[0])
+}
+
 func TestNoteTitle(t *testing.T) {
 	writer := &MockWriter{}
 	prompts.Note("test", prompts.NoteOptions{Output: writer, Title: "Title Test"})
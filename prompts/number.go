@@ -0,0 +1,49 @@
+package prompts
+
+import (
+	"context"
+
+	"github.com/Mist3rBru/go-clack/core"
+	"github.com/Mist3rBru/go-clack/prompts/test"
+	"github.com/Mist3rBru/go-clack/prompts/theme"
+)
+
+type NumberParams[T core.Number] struct {
+	Name         string
+	Message      string
+	InitialValue T
+	Min          *T
+	Max          *T
+	Step         T
+	Separator    string
+	Unit         string
+	Required     bool
+	Validate     func(value T) error
+}
+
+func Number[T core.Number](params NumberParams[T]) (T, error) {
+	return NumberContext(context.Background(), params)
+}
+
+func NumberContext[T core.Number](ctx context.Context, params NumberParams[T]) (T, error) {
+	p := core.NewNumberPrompt(core.NumberPromptParams[T]{
+		InitialValue: params.InitialValue,
+		Min:          params.Min,
+		Max:          params.Max,
+		Step:         params.Step,
+		Separator:    params.Separator,
+		Unit:         params.Unit,
+		Required:     params.Required,
+		Validate:     params.Validate,
+		Render: func(p *core.NumberPrompt[T]) string {
+			return theme.ApplyTheme(theme.ThemeParams[T]{
+				Ctx:             p.Prompt,
+				Message:         params.Message,
+				Value:           p.FormattedValue(),
+				ValueWithCursor: p.ValueWithCursor(),
+			})
+		},
+	})
+	test.NumberTestingPrompt = p
+	return runPrompt(ctx, &p.Prompt, params.Name)
+}
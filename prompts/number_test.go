@@ -0,0 +1,65 @@
+package prompts_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mist3rBru/go-clack/core"
+	"github.com/Mist3rBru/go-clack/prompts"
+	"github.com/Mist3rBru/go-clack/prompts/symbols"
+	"github.com/Mist3rBru/go-clack/prompts/test"
+	"github.com/Mist3rBru/go-clack/third_party/picocolors"
+	"github.com/stretchr/testify/assert"
+)
+
+func runNumber[T core.Number](t *testing.T, params prompts.NumberParams[T]) (*core.NumberPrompt[T], <-chan T) {
+	test.NumberTestingPrompt = nil
+	result := make(chan T, 1)
+	go func() {
+		value, _ := prompts.Number(params)
+		result <- value
+	}()
+
+	var p *core.NumberPrompt[T]
+	assert.Eventually(t, func() bool {
+		p, _ = test.NumberTestingPrompt.(*core.NumberPrompt[T])
+		return p != nil
+	}, time.Second, time.Millisecond)
+	return p, result
+}
+
+func TestNumberInitialState(t *testing.T) {
+	p, _ := runNumber(t, prompts.NumberParams[int]{
+		Message:      message,
+		InitialValue: 1500,
+		Separator:    ",",
+		Unit:         "ms",
+	})
+
+	title := symbols.State(core.InitialState) + " " + message
+	valueWithCursor := symbols.BAR + " 1,500" + picocolors.Inverse(" ") + " ms"
+	expected := strings.Join([]string{symbols.BAR, title, valueWithCursor, symbols.BAR_END}, "\r\n")
+	assert.Equal(t, core.InitialState, p.State)
+	assert.Equal(t, expected, p.Frame)
+}
+
+func TestNumberSubmitState(t *testing.T) {
+	p, result := runNumber(t, prompts.NumberParams[float64]{
+		Message: message,
+		Step:    0.5,
+		Unit:    "kg",
+	})
+
+	p.PressKey(&core.Key{Name: core.UpKey})
+	p.PressKey(&core.Key{Name: core.UpKey})
+	p.PressKey(&core.Key{Name: core.UpKey})
+	p.PressKey(&core.Key{Name: core.EnterKey})
+
+	title := symbols.State(core.SubmitState) + " " + message
+	value := symbols.BAR + " 1.5 kg"
+	expected := strings.Join([]string{symbols.BAR, title, value}, "\r\n")
+	assert.Equal(t, core.SubmitState, p.State)
+	assert.Equal(t, expected, p.Frame)
+	assert.Equal(t, 1.5, <-result)
+}
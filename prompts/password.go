@@ -1,12 +1,15 @@
 package prompts
 
 import (
+	"context"
+
 	"github.com/Mist3rBru/go-clack/core"
 	"github.com/Mist3rBru/go-clack/prompts/test"
 	"github.com/Mist3rBru/go-clack/prompts/theme"
 )
 
 type PasswordParams struct {
+	Name         string
 	Message      string
 	InitialValue string
 	Required     bool
@@ -14,6 +17,10 @@ type PasswordParams struct {
 }
 
 func Password(params PasswordParams) (string, error) {
+	return PasswordContext(context.Background(), params)
+}
+
+func PasswordContext(ctx context.Context, params PasswordParams) (string, error) {
 	p := core.NewPasswordPrompt(core.PasswordPromptParams{
 		InitialValue: params.InitialValue,
 		Required:     params.Required,
@@ -28,5 +35,5 @@ func Password(params PasswordParams) (string, error) {
 		},
 	})
 	test.PasswordTestingPrompt = p
-	return p.Run()
+	return runPrompt(ctx, &p.Prompt, params.Name)
 }
@@ -1,6 +1,8 @@
 package prompts
 
 import (
+	"context"
+
 	"github.com/Mist3rBru/go-clack/core"
 	"github.com/Mist3rBru/go-clack/prompts/test"
 	"github.com/Mist3rBru/go-clack/prompts/theme"
@@ -8,6 +10,7 @@ import (
 )
 
 type PathParams struct {
+	Name         string
 	Message      string
 	InitialValue string
 	OnlyShowDir  bool
@@ -16,6 +19,10 @@ type PathParams struct {
 }
 
 func Path(params PathParams) (string, error) {
+	return PathContext(context.Background(), params)
+}
+
+func PathContext(ctx context.Context, params PathParams) (string, error) {
 	p := core.NewPathPrompt(core.PathPromptParams{
 		InitialValue: params.InitialValue,
 		OnlyShowDir:  params.OnlyShowDir,
@@ -48,5 +55,5 @@ func Path(params PathParams) (string, error) {
 		},
 	})
 	test.PathTestingPrompt = p
-	return p.Run()
+	return runPrompt(ctx, &p.Prompt, params.Name)
 }
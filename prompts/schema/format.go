@@ -0,0 +1,60 @@
+package schema
+
+import (
+	"errors"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	uuidPattern     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	hostnamePattern = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
+)
+
+// validateFormat validates a string against a JSON Schema format.
+// Unknown formats are annotations only, so any string is valid.
+func validateFormat(format, str string) error {
+	switch format {
+	case "email":
+		if address, err := mail.ParseAddress(str); err != nil || address.Address != str {
+			return errors.New("Please enter a valid email address.")
+		}
+	case "uri", "url":
+		if u, err := url.Parse(str); err != nil || u.Scheme == "" {
+			return errors.New("Please enter a valid URI, like https://example.com.")
+		}
+	case "date":
+		if _, err := time.Parse(time.DateOnly, str); err != nil {
+			return errors.New("Please enter a valid date, like 2006-01-02.")
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, str); err != nil {
+			return errors.New("Please enter a valid date and time, like 2006-01-02T15:04:05Z.")
+		}
+	case "time":
+		if _, err := time.Parse(time.TimeOnly, str); err != nil {
+			return errors.New("Please enter a valid time, like 15:04:05.")
+		}
+	case "uuid":
+		if !uuidPattern.MatchString(str) {
+			return errors.New("Please enter a valid UUID.")
+		}
+	case "ipv4":
+		if ip := net.ParseIP(str); ip == nil || ip.To4() == nil || strings.Contains(str, ":") {
+			return errors.New("Please enter a valid IPv4 address.")
+		}
+	case "ipv6":
+		if ip := net.ParseIP(str); ip == nil || !strings.Contains(str, ":") {
+			return errors.New("Please enter a valid IPv6 address.")
+		}
+	case "hostname":
+		if len(str) > 253 || !hostnamePattern.MatchString(str) {
+			return errors.New("Please enter a valid hostname.")
+		}
+	}
+	return nil
+}
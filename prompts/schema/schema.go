@@ -0,0 +1,432 @@
+// Package schema prompts for the properties of a JSON Schema document.
+package schema
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Mist3rBru/go-clack/prompts"
+)
+
+// Schema is the subset of a JSON Schema used to choose and validate prompts.
+type Schema struct {
+	Type             Type       `json:"type"`
+	Title            string     `json:"title"`
+	Description      string     `json:"description"`
+	Default          any        `json:"default"`
+	Enum             []any      `json:"enum"`
+	Properties       Properties `json:"properties"`
+	Required         []string   `json:"required"`
+	Items            *Schema    `json:"items"`
+	MinItems         *int       `json:"minItems"`
+	MaxItems         *int       `json:"maxItems"`
+	Format           string     `json:"format"`
+	Pattern          string     `json:"pattern"`
+	MinLength        *int       `json:"minLength"`
+	MaxLength        *int       `json:"maxLength"`
+	Minimum          *float64   `json:"minimum"`
+	Maximum          *float64   `json:"maximum"`
+	ExclusiveMinimum *float64   `json:"exclusiveMinimum"`
+	ExclusiveMaximum *float64   `json:"exclusiveMaximum"`
+	ReadOnly         bool       `json:"readOnly"`
+	WriteOnly        bool       `json:"writeOnly"`
+
+	pattern *regexp.Regexp
+}
+
+// Type is the JSON type of a schema. A list of types resolves to its first non-null type.
+type Type string
+
+func (t *Type) UnmarshalJSON(data []byte) error {
+	var types []string
+	if err := json.Unmarshal(data, &types); err != nil {
+		var str string
+		if err := json.Unmarshal(data, &str); err != nil {
+			return err
+		}
+		types = []string{str}
+	}
+
+	for _, str := range types {
+		if str != "null" {
+			*t = Type(str)
+			break
+		}
+	}
+	return nil
+}
+
+// Property is a named property of an object schema.
+type Property struct {
+	Name   string
+	Schema *Schema
+}
+
+// Properties are the properties of an object schema, in the document's order.
+type Properties []Property
+
+func (p *Properties) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token != json.Delim('{') {
+		return errors.New("expected properties to be an object")
+	}
+
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		var schema Schema
+		if err := dec.Decode(&schema); err != nil {
+			return err
+		}
+		*p = append(*p, Property{Name: token.(string), Schema: &schema})
+	}
+	return nil
+}
+
+// Parse parses a JSON Schema document, which must describe an object.
+func Parse(data []byte) (*Schema, error) {
+	var s Schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("schema error: %w", err)
+	}
+	if s.Type != "object" {
+		return nil, fmt.Errorf("schema error: expected an object schema, got `%s`", s.Type)
+	}
+	if err := s.compile(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// compile compiles the patterns of the schema and its subschemas.
+// Patterns are compiled with Go's RE2 syntax, which lacks the lookarounds and backreferences of ECMA-262.
+func (s *Schema) compile() error {
+	if s.Pattern != "" {
+		pattern, err := regexp.Compile(s.Pattern)
+		var syntaxErr *syntax.Error
+		if errors.As(err, &syntaxErr) && (syntaxErr.Code == syntax.ErrInvalidPerlOp || syntaxErr.Code == syntax.ErrInvalidEscape) {
+			return fmt.Errorf("schema error: unsupported pattern `%s`, as lookarounds and backreferences are not supported: %w", s.Pattern, err)
+		}
+		if err != nil {
+			return fmt.Errorf("schema error: invalid pattern `%s`: %w", s.Pattern, err)
+		}
+		s.pattern = pattern
+	}
+	if s.Items != nil {
+		if err := s.Items.compile(); err != nil {
+			return err
+		}
+	}
+	for _, property := range s.Properties {
+		if err := property.Schema.compile(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Prompt prompts for each property of the schema, returning the answers as a map.
+func Prompt(data []byte) (map[string]any, error) {
+	return PromptContext(context.Background(), data)
+}
+
+// PromptContext prompts for each property of the schema, returning the answers as a map.
+// Answers set with prompts.WithAnswers are looked up by property name, nested properties being dotted like `database.host`.
+func PromptContext(ctx context.Context, data []byte) (map[string]any, error) {
+	s, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	return s.Run(ctx)
+}
+
+// PromptJSON prompts for each property of the schema, returning the answers as a JSON document.
+func PromptJSON(data []byte) ([]byte, error) {
+	return PromptJSONContext(context.Background(), data)
+}
+
+// PromptJSONContext prompts for each property of the schema, returning the answers as a JSON document.
+func PromptJSONContext(ctx context.Context, data []byte) ([]byte, error) {
+	result, err := PromptContext(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(result)
+}
+
+// Run prompts for each property of an object schema, in order.
+// Enums are prompted with Select, arrays of enums with MultiSelect, booleans with Confirm,
+// nested objects as sections titled by a step, write-only strings with Password and other values with Text,
+// validated by their format, pattern, length and range.
+// Patterns use Go's RE2 syntax, so lookarounds and backreferences are rejected when the schema is parsed.
+// Read-only properties are set to their default, and optional properties left empty are omitted.
+func (s *Schema) Run(ctx context.Context) (map[string]any, error) {
+	return s.runObject(ctx, "")
+}
+
+func (s *Schema) runObject(ctx context.Context, path string) (map[string]any, error) {
+	result := make(map[string]any)
+	for _, property := range s.Properties {
+		name := property.Name
+		if path != "" {
+			name = path + "." + name
+		}
+
+		required := slices.Contains(s.Required, property.Name)
+		value, ok, err := property.Schema.run(ctx, name, property.Name, required)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			result[property.Name] = value
+		}
+	}
+	return result, nil
+}
+
+// run prompts for the value of a property, reporting whether it was set.
+func (s *Schema) run(ctx context.Context, name, label string, required bool) (any, bool, error) {
+	message := s.Title
+	if message == "" {
+		message = label
+	}
+
+	switch {
+	case s.ReadOnly:
+		return s.Default, s.Default != nil, nil
+
+	case len(s.Enum) > 0:
+		// The enum values are selected by their formatted value, as arrays and objects are not comparable
+		options := make([]*prompts.SelectOption[string], len(s.Enum))
+		for i, value := range s.Enum {
+			options[i] = &prompts.SelectOption[string]{Label: formatValue(value), Value: formatValue(value)}
+		}
+		initialValue := ""
+		if s.Default != nil {
+			initialValue = formatValue(s.Default)
+		}
+		value, err := prompts.SelectContext(ctx, prompts.SelectParams[string]{
+			Name:         name,
+			Message:      message,
+			InitialValue: initialValue,
+			Options:      options,
+		})
+		if err != nil {
+			return nil, false, err
+		}
+		return enumValue(s.Enum, value), true, nil
+
+	case s.Type == "object":
+		prompts.Step(message)
+		value, err := s.runObject(ctx, name)
+		return value, err == nil, err
+
+	case s.Type == "boolean":
+		initialValue, _ := s.Default.(bool)
+		value, err := prompts.ConfirmContext(ctx, prompts.ConfirmParams{
+			Name:         name,
+			Message:      message,
+			InitialValue: initialValue,
+		})
+		return value, err == nil, err
+
+	case s.Type == "array" && s.Items != nil && len(s.Items.Enum) > 0:
+		options := make([]*prompts.MultiSelectOption[string], len(s.Items.Enum))
+		for i, value := range s.Items.Enum {
+			options[i] = &prompts.MultiSelectOption[string]{Label: formatValue(value), Value: formatValue(value)}
+		}
+		defaultValue, _ := s.Default.([]any)
+		var initialValue []string
+		for _, value := range defaultValue {
+			initialValue = append(initialValue, formatValue(value))
+		}
+		selected, err := prompts.MultiSelectContext(ctx, prompts.MultiSelectParams[string]{
+			Name:         name,
+			Message:      message,
+			InitialValue: initialValue,
+			Options:      options,
+			Required:     s.MinItems != nil && *s.MinItems > 0,
+			Validate: func(value []string) error {
+				return s.validateItems(len(value))
+			},
+		})
+		if err != nil {
+			return nil, false, err
+		}
+		value := make([]any, len(selected))
+		for i, item := range selected {
+			value[i] = enumValue(s.Items.Enum, item)
+		}
+		return value, required || len(value) > 0, nil
+	}
+
+	validate := func(value string) error {
+		if value == "" {
+			return nil
+		}
+		_, err := s.parse(value)
+		return err
+	}
+
+	var value string
+	var err error
+	if s.WriteOnly {
+		value, err = prompts.PasswordContext(ctx, prompts.PasswordParams{
+			Name:         name,
+			Message:      message,
+			InitialValue: s.formatDefault(),
+			Required:     required,
+			Validate:     validate,
+		})
+	} else {
+		value, err = prompts.TextContext(ctx, prompts.TextParams{
+			Name:         name,
+			Message:      message,
+			Placeholder:  s.Description,
+			InitialValue: s.formatDefault(),
+			Required:     required,
+			Validate:     validate,
+		})
+	}
+	if err != nil || value == "" {
+		return nil, false, err
+	}
+
+	result, err := s.parse(value)
+	return result, err == nil, err
+}
+
+// parse parses a text answer into a value of the schema's type, validating it.
+// Arrays are parsed from comma-separated items.
+func (s *Schema) parse(str string) (any, error) {
+	switch s.Type {
+	case "integer":
+		n, err := strconv.ParseInt(str, 10, 64)
+		if err != nil {
+			return nil, errors.New("Please enter a valid integer.")
+		}
+		return n, s.validateNumber(float64(n))
+
+	case "number":
+		n, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return nil, errors.New("Please enter a valid number.")
+		}
+		return n, s.validateNumber(n)
+
+	case "boolean":
+		b, err := strconv.ParseBool(str)
+		if err != nil {
+			return nil, errors.New("Please enter true or false.")
+		}
+		return b, nil
+
+	case "array":
+		items := s.Items
+		if items == nil {
+			items = &Schema{Type: "string"}
+		}
+		var values []any
+		for _, item := range strings.Split(str, ",") {
+			value, err := items.parse(strings.TrimSpace(item))
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, s.validateItems(len(values))
+	}
+
+	return str, s.validateString(str)
+}
+
+func (s *Schema) validateString(str string) error {
+	length := len([]rune(str))
+	if s.MinLength != nil && length < *s.MinLength {
+		return fmt.Errorf("Please enter at least %d characters.", *s.MinLength)
+	}
+	if s.MaxLength != nil && length > *s.MaxLength {
+		return fmt.Errorf("Please enter at most %d characters.", *s.MaxLength)
+	}
+	if s.pattern != nil && !s.pattern.MatchString(str) {
+		return fmt.Errorf("Please enter a value matching %s.", s.Pattern)
+	}
+	return validateFormat(s.Format, str)
+}
+
+func (s *Schema) validateNumber(n float64) error {
+	if s.Minimum != nil && n < *s.Minimum {
+		return fmt.Errorf("Please enter a number greater than or equal to %v.", *s.Minimum)
+	}
+	if s.ExclusiveMinimum != nil && n <= *s.ExclusiveMinimum {
+		return fmt.Errorf("Please enter a number greater than %v.", *s.ExclusiveMinimum)
+	}
+	if s.Maximum != nil && n > *s.Maximum {
+		return fmt.Errorf("Please enter a number less than or equal to %v.", *s.Maximum)
+	}
+	if s.ExclusiveMaximum != nil && n >= *s.ExclusiveMaximum {
+		return fmt.Errorf("Please enter a number less than %v.", *s.ExclusiveMaximum)
+	}
+	return nil
+}
+
+func (s *Schema) validateItems(count int) error {
+	if s.MinItems != nil && count < *s.MinItems {
+		return fmt.Errorf("Please enter at least %d items.", *s.MinItems)
+	}
+	if s.MaxItems != nil && count > *s.MaxItems {
+		return fmt.Errorf("Please enter at most %d items.", *s.MaxItems)
+	}
+	return nil
+}
+
+// formatDefault formats the default value as the initial value of a text prompt.
+func (s *Schema) formatDefault() string {
+	if s.Default == nil {
+		return ""
+	}
+	if values, ok := s.Default.([]any); ok {
+		items := make([]string, len(values))
+		for i, value := range values {
+			items[i] = formatValue(value)
+		}
+		return strings.Join(items, ",")
+	}
+	return formatValue(s.Default)
+}
+
+// enumValue returns the enum value selected by its formatted value.
+func enumValue(enum []any, formatted string) any {
+	for _, value := range enum {
+		if formatValue(value) == formatted {
+			return value
+		}
+	}
+	return nil
+}
+
+// formatValue formats a JSON value as a label, keeping strings unquoted.
+func formatValue(value any) string {
+	if str, ok := value.(string); ok {
+		return str
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
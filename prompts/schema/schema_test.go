@@ -0,0 +1,162 @@
+package schema_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Mist3rBru/go-clack/core"
+	"github.com/Mist3rBru/go-clack/prompts"
+	"github.com/Mist3rBru/go-clack/prompts/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+const configSchema = `{
+	"type": "object",
+	"required": ["name", "email"],
+	"properties": {
+		"name": {"type": "string", "title": "Project name", "pattern": "^[a-z-]+$"},
+		"email": {"type": "string", "format": "email"},
+		"token": {"type": "string", "writeOnly": true},
+		"port": {"type": "integer", "minimum": 1, "maximum": 65535, "default": 8080},
+		"ratio": {"type": ["number", "null"]},
+		"manager": {"type": "string", "enum": ["npm", "yarn", "pnpm"]},
+		"tools": {"type": "array", "items": {"enum": ["eslint", "prettier", "jest"]}},
+		"tags": {"type": "array", "items": {"type": "string"}},
+		"install": {"type": "boolean"},
+		"version": {"type": "string", "readOnly": true, "default": "1.0.0"},
+		"notes": {"type": "string"},
+		"database": {
+			"type": "object",
+			"title": "Database",
+			"properties": {
+				"host": {"type": "string", "format": "hostname"}
+			}
+		}
+	}
+}`
+
+func TestParse(t *testing.T) {
+	s, err := schema.Parse([]byte(configSchema))
+
+	assert.NoError(t, err)
+	assert.Equal(t, schema.Type("object"), s.Type)
+	assert.Len(t, s.Properties, 12)
+	assert.Equal(t, "name", s.Properties[0].Name)
+	assert.Equal(t, "database", s.Properties[11].Name)
+	assert.Equal(t, schema.Type("number"), s.Properties[4].Schema.Type)
+}
+
+func TestParseInvalidSchema(t *testing.T) {
+	_, err := schema.Parse([]byte(`{"type": "string"}`))
+	assert.ErrorContains(t, err, "expected an object schema")
+
+	_, err = schema.Parse([]byte(`{"type": "object", "properties": {"name": {"pattern": "("}}}`))
+	assert.ErrorContains(t, err, "invalid pattern")
+
+	_, err = schema.Parse([]byte(`{"type": "object", "properties": {"name": {"pattern": "^(?!admin).+$"}}}`))
+	assert.ErrorContains(t, err, "unsupported pattern")
+
+	_, err = schema.Parse([]byte(`{"type": "object", "properties": {"name": {"pattern": "^(a)\\1$"}}}`))
+	assert.ErrorContains(t, err, "unsupported pattern")
+}
+
+func TestPromptWithAnswers(t *testing.T) {
+	ctx := prompts.WithAnswers(context.Background(), prompts.MapAnswers{
+		"name":          "my-app",
+		"email":         "foo@example.com",
+		"token":         "secret",
+		"port":          8080,
+		"ratio":         "0.5",
+		"manager":       "yarn",
+		"tools":         []any{"eslint", "jest"},
+		"tags":          "web, api",
+		"install":       true,
+		"notes":         "",
+		"database.host": "db.example.com",
+	})
+
+	result, err := schema.PromptContext(ctx, []byte(configSchema))
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"name":     "my-app",
+		"email":    "foo@example.com",
+		"token":    "secret",
+		"port":     int64(8080),
+		"ratio":    0.5,
+		"manager":  "yarn",
+		"tools":    []any{"eslint", "jest"},
+		"tags":     []any{"web", "api"},
+		"install":  true,
+		"version":  "1.0.0",
+		"database": map[string]any{"host": "db.example.com"},
+	}, result)
+}
+
+func TestPromptJSONWithAnswers(t *testing.T) {
+	ctx := prompts.WithAnswers(context.Background(), prompts.MapAnswers{
+		"name":  "my-app",
+		"email": "foo@example.com",
+		"port":  "3000",
+	})
+
+	result, err := schema.PromptJSONContext(ctx, []byte(`{
+		"type": "object",
+		"properties": {
+			"name": {"type": "string"},
+			"email": {"type": "string"},
+			"port": {"type": "integer"}
+		}
+	}`))
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"name": "my-app", "email": "foo@example.com", "port": 3000}`, string(result))
+}
+
+func TestPromptWithInvalidAnswers(t *testing.T) {
+	for _, tc := range []struct {
+		property string
+		answer   string
+		msg      string
+	}{
+		{`{"type": "string", "pattern": "^[a-z-]+$"}`, "My App", "Please enter a value matching ^[a-z-]+$."},
+		{`{"type": "string", "minLength": 3}`, "ab", "Please enter at least 3 characters."},
+		{`{"type": "integer"}`, "eighty", "Please enter a valid integer."},
+		{`{"type": "integer", "minimum": 1}`, "0", "Please enter a number greater than or equal to 1."},
+		{`{"type": "array", "maxItems": 1}`, "a,b", "Please enter at most 1 items."},
+		{`{"type": "string", "format": "email"}`, "foo@", "Please enter a valid email address."},
+		{`{"type": "string", "format": "hostname"}`, "-db-.local", "Please enter a valid hostname."},
+		{`{"type": "string", "format": "date"}`, "2024-13-01", "Please enter a valid date, like 2006-01-02."},
+		{`{"type": "string", "format": "uuid"}`, "not-a-uuid", "Please enter a valid UUID."},
+		{`{"type": "string", "format": "ipv4"}`, "256.0.0.1", "Please enter a valid IPv4 address."},
+		{`{"type": "string", "format": "uri"}`, "example.com", "Please enter a valid URI, like https://example.com."},
+	} {
+		ctx := prompts.WithAnswers(context.Background(), prompts.MapAnswers{"value": tc.answer})
+
+		_, err := schema.PromptContext(ctx, []byte(`{"type": "object", "properties": {"value": `+tc.property+`}}`))
+
+		assert.ErrorIs(t, err, core.ErrInvalidAnswer, tc.answer)
+		assert.ErrorContains(t, err, tc.msg, tc.answer)
+	}
+}
+
+func TestPromptObjectAndArrayEnumsWithAnswers(t *testing.T) {
+	ctx := prompts.WithAnswers(context.Background(), prompts.MapAnswers{
+		"size":  `{"size":"l"}`,
+		"range": "[3,4]",
+	})
+
+	result, err := schema.PromptContext(ctx, []byte(`{
+		"type": "object",
+		"properties": {
+			"size": {"enum": [{"size": "s"}, {"size": "l"}], "default": {"size": "s"}},
+			"range": {"enum": [[1, 2], [3, 4]]}
+		}
+	}`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"size":  map[string]any{"size": "l"},
+		"range": []any{float64(3), float64(4)},
+	}, result)
+}
@@ -1,6 +1,7 @@
 package prompts
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/Mist3rBru/go-clack/core"
@@ -17,11 +18,16 @@ type SelectKeyOption[TValue comparable] struct {
 }
 
 type SelectKeyParams[TValue comparable] struct {
+	Name    string
 	Message string
 	Options []SelectKeyOption[TValue]
 }
 
 func SelectKey[TValue comparable](params SelectKeyParams[TValue]) (TValue, error) {
+	return SelectKeyContext(context.Background(), params)
+}
+
+func SelectKeyContext[TValue comparable](ctx context.Context, params SelectKeyParams[TValue]) (TValue, error) {
 	v := validator.NewValidator("SelectKey")
 	v.ValidateOptions(len(params.Options))
 
@@ -59,5 +65,5 @@ func SelectKey[TValue comparable](params SelectKeyParams[TValue]) (TValue, error
 		},
 	})
 	test.SelectKeyTestingPrompt = p
-	return p.Run()
+	return runPrompt(ctx, &p.Prompt, params.Name)
 }
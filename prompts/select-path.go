@@ -1,6 +1,7 @@
 package prompts
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -14,18 +15,26 @@ import (
 type FileSystem = core.FileSystem
 
 type SelectPathParams struct {
+	Name         string
 	Message      string
 	InitialValue string
 	OnlyShowDir  bool
 	Filter       bool
-	FileSystem   FileSystem
+	// RegexFilter matches the search as a regular expression, instead of fuzzily
+	RegexFilter bool
+	FileSystem  FileSystem
 }
 
 func SelectPath(params SelectPathParams) (string, error) {
+	return SelectPathContext(context.Background(), params)
+}
+
+func SelectPathContext(ctx context.Context, params SelectPathParams) (string, error) {
 	p := core.NewSelectPathPrompt(core.SelectPathPromptParams{
 		InitialValue: params.InitialValue,
 		OnlyShowDir:  params.OnlyShowDir,
 		Filter:       params.Filter,
+		RegexFilter:  params.RegexFilter,
 		FileSystem:   params.FileSystem,
 		Render: func(p *core.SelectPathPrompt) string {
 			message := params.Message
@@ -45,10 +54,10 @@ func SelectPath(params SelectPathParams) (string, error) {
 					}
 					if option.IsEqual(p.CurrentOption) {
 						radio = picocolors.Green(symbols.RADIO_ACTIVE)
-						label = option.Name
+						label = core.HighlightMatches(option.Name, option.Matches, picocolors.Underline)
 					} else {
 						radio = picocolors.Dim(symbols.RADIO_INACTIVE)
-						label = picocolors.Dim(option.Name)
+						label = picocolors.Dim(core.HighlightMatches(option.Name, option.Matches, picocolors.Underline))
 						dir = picocolors.Dim(dir)
 					}
 					depth := strings.Repeat(" ", option.Depth)
@@ -78,5 +87,5 @@ func SelectPath(params SelectPathParams) (string, error) {
 		},
 	})
 	test.SelectPathTestingPrompt = p
-	return p.Run()
+	return runPrompt(ctx, &p.Prompt, params.Name)
 }
@@ -1,7 +1,9 @@
 package prompts
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	"github.com/Mist3rBru/go-clack/core"
 	"github.com/Mist3rBru/go-clack/core/validator"
@@ -14,33 +16,52 @@ import (
 type SelectOption[TValue comparable] struct {
 	Label string
 	Value TValue
-	Hint  string
+	// Hint is shown for the option under the cursor, or always as the reason of a disabled option
+	Hint string
+	// IsDisabled shows the option without letting it be selected
+	IsDisabled bool
+	// IsSeparator shows the label as a heading between the options
+	IsSeparator bool
 }
 
 type SelectParams[TValue comparable] struct {
+	Name         string
 	Message      string
 	InitialValue TValue
 	Options      []*SelectOption[TValue]
 	Filter       bool
-	Required     bool
+	// RegexFilter matches the search as a regular expression, instead of fuzzily
+	RegexFilter bool
+	Required    bool
 }
 
 func Select[TValue comparable](params SelectParams[TValue]) (TValue, error) {
+	return SelectContext(context.Background(), params)
+}
+
+func SelectContext[TValue comparable](ctx context.Context, params SelectParams[TValue]) (TValue, error) {
 	v := validator.NewValidator("Select")
 	v.ValidateOptions(len(params.Options))
 
 	var options []*core.SelectOption[TValue]
+	// The hints are looked up by option, as filtering reorders the options
+	hints := make(map[*core.SelectOption[TValue]]string)
 	for _, option := range params.Options {
-		options = append(options, &core.SelectOption[TValue]{
-			Label: option.Label,
-			Value: option.Value,
-		})
+		coreOption := &core.SelectOption[TValue]{
+			Label:       option.Label,
+			Value:       option.Value,
+			IsDisabled:  option.IsDisabled,
+			IsSeparator: option.IsSeparator,
+		}
+		hints[coreOption] = option.Hint
+		options = append(options, coreOption)
 	}
 
 	p := core.NewSelectPrompt(core.SelectPromptParams[TValue]{
 		InitialValue: params.InitialValue,
 		Options:      options,
 		Filter:       params.Filter,
+		RegexFilter:  params.RegexFilter,
 		Required:     params.Required,
 		Render: func(p *core.SelectPrompt[TValue]) string {
 			message := params.Message
@@ -53,28 +74,34 @@ func Select[TValue comparable](params SelectParams[TValue]) (TValue, error) {
 				}
 			default:
 				radioOptions := make([]string, len(p.Options))
-				for _, option := range params.Options {
-					for i, _option := range p.Options {
-						if option.Label != _option.Label {
-							continue
-						}
-
-						if i == p.CursorIndex && option.Hint != "" {
-							radio := picocolors.Green(symbols.RADIO_ACTIVE)
-							label := option.Label
-							hint := picocolors.Dim("(" + option.Hint + ")")
-							radioOptions[i] = fmt.Sprintf("%s %s %s", radio, label, hint)
-						} else if i == p.CursorIndex {
-							radio := picocolors.Green(symbols.RADIO_ACTIVE)
-							label := option.Label
-							radioOptions[i] = fmt.Sprintf("%s %s", radio, label)
-						} else {
-							radio := picocolors.Dim(symbols.RADIO_INACTIVE)
-							label := picocolors.Dim(option.Label)
-							radioOptions[i] = fmt.Sprintf("%s %s", radio, label)
-						}
+				for i, option := range p.Options {
+					if option.IsSeparator {
+						radioOptions[i] = separatorLine(option.Label)
+						continue
+					}
 
-						break
+					if option.IsDisabled && hints[option] != "" {
+						radio := picocolors.Dim(symbols.RADIO_INACTIVE)
+						label := picocolors.Dim(picocolors.Strikethrough(option.Label))
+						hint := picocolors.Dim("(" + hints[option] + ")")
+						radioOptions[i] = fmt.Sprintf("%s %s %s", radio, label, hint)
+					} else if option.IsDisabled {
+						radio := picocolors.Dim(symbols.RADIO_INACTIVE)
+						label := picocolors.Dim(picocolors.Strikethrough(option.Label))
+						radioOptions[i] = fmt.Sprintf("%s %s", radio, label)
+					} else if i == p.CursorIndex && hints[option] != "" {
+						radio := picocolors.Green(symbols.RADIO_ACTIVE)
+						label := core.HighlightMatches(option.Label, option.Matches, picocolors.Underline)
+						hint := picocolors.Dim("(" + hints[option] + ")")
+						radioOptions[i] = fmt.Sprintf("%s %s %s", radio, label, hint)
+					} else if i == p.CursorIndex {
+						radio := picocolors.Green(symbols.RADIO_ACTIVE)
+						label := core.HighlightMatches(option.Label, option.Matches, picocolors.Underline)
+						radioOptions[i] = fmt.Sprintf("%s %s", radio, label)
+					} else {
+						radio := picocolors.Dim(symbols.RADIO_INACTIVE)
+						label := picocolors.Dim(core.HighlightMatches(option.Label, option.Matches, picocolors.Underline))
+						radioOptions[i] = fmt.Sprintf("%s %s", radio, label)
 					}
 				}
 
@@ -101,5 +128,13 @@ func Select[TValue comparable](params SelectParams[TValue]) (TValue, error) {
 		},
 	})
 	test.SelectTestingPrompt = p
-	return p.Run()
+	return runPrompt(ctx, &p.Prompt, params.Name)
+}
+
+// separatorLine renders a separator option, as a heading with its label.
+func separatorLine(label string) string {
+	if label == "" {
+		return picocolors.Dim(strings.Repeat(symbols.BAR_H, 3))
+	}
+	return picocolors.Dim(symbols.BAR_H + " " + label)
 }
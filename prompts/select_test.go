@@ -6,6 +6,7 @@ import (
 
 	"github.com/Mist3rBru/go-clack/core"
 	"github.com/Mist3rBru/go-clack/prompts"
+	"github.com/Mist3rBru/go-clack/prompts/symbols"
 	"github.com/Mist3rBru/go-clack/prompts/test"
 	"github.com/bradleyjkemp/cupaloy"
 	"github.com/stretchr/testify/assert"
@@ -47,6 +48,24 @@ func TestSelectWithHint(t *testing.T) {
 	cupaloy.SnapshotT(t, p.Frame)
 }
 
+func TestSelectWithDisabledOptions(t *testing.T) {
+	go prompts.Select(prompts.SelectParams[string]{
+		Message: message,
+		Options: []*prompts.SelectOption[string]{
+			{Label: "Databases", IsSeparator: true},
+			{Label: "postgres", IsDisabled: true, Hint: "requires license"},
+			{Label: "mysql"},
+			{IsSeparator: true},
+			{Label: "redis", IsDisabled: true},
+		},
+	})
+	time.Sleep(time.Millisecond)
+	p := test.SelectTestingPrompt.(*core.SelectPrompt[string])
+
+	assert.Equal(t, 2, p.CursorIndex)
+	cupaloy.SnapshotT(t, p.Frame)
+}
+
 func TestSelectCancelState(t *testing.T) {
 	go runSelect()
 	time.Sleep(time.Millisecond)
@@ -130,3 +149,44 @@ func TestSelectFilledFilter(t *testing.T) {
 	assert.Equal(t, core.ActiveState, p.State)
 	cupaloy.SnapshotT(t, p.Frame)
 }
+
+func TestSelectFuzzyFilter(t *testing.T) {
+	go prompts.Select(prompts.SelectParams[string]{
+		Message: message,
+		Filter:  true,
+		Options: []*prompts.SelectOption[string]{
+			{Label: "foo"},
+			{Label: "bar"},
+			{Label: "baz"},
+		},
+	})
+	time.Sleep(time.Millisecond)
+
+	p := test.SelectTestingPrompt.(*core.SelectPrompt[string])
+	p.PressKey(&core.Key{Char: "b"})
+	p.PressKey(&core.Key{Char: "r"})
+
+	assert.Contains(t, p.Frame, "> br")
+	assert.Contains(t, p.Frame, symbols.RADIO_ACTIVE+" bar")
+	assert.NotContains(t, p.Frame, "baz")
+}
+
+func TestSelectRegexFilter(t *testing.T) {
+	go prompts.Select(prompts.SelectParams[string]{
+		Message:     message,
+		Filter:      true,
+		RegexFilter: true,
+		Options: []*prompts.SelectOption[string]{
+			{Label: "foo"},
+			{Label: "bar"},
+			{Label: "baz"},
+		},
+	})
+	time.Sleep(time.Millisecond)
+
+	p := test.SelectTestingPrompt.(*core.SelectPrompt[string])
+	p.PressKey(&core.Key{Char: "b"})
+	p.PressKey(&core.Key{Char: "r"})
+
+	assert.Empty(t, p.Options)
+}
@@ -0,0 +1,98 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Mist3rBru/go-clack/core"
+	"github.com/Mist3rBru/go-clack/core/validator"
+	"github.com/Mist3rBru/go-clack/prompts/symbols"
+	"github.com/Mist3rBru/go-clack/prompts/test"
+	"github.com/Mist3rBru/go-clack/prompts/theme"
+	"github.com/Mist3rBru/go-clack/third_party/picocolors"
+)
+
+type SortOption[TValue any] struct {
+	Label string
+	Value TValue
+	Hint  string
+}
+
+type SortParams[TValue any] struct {
+	Name     string
+	Message  string
+	Options  []*SortOption[TValue]
+	Validate func(value []TValue) error
+}
+
+func Sort[TValue any](params SortParams[TValue]) ([]TValue, error) {
+	return SortContext(context.Background(), params)
+}
+
+func SortContext[TValue any](ctx context.Context, params SortParams[TValue]) ([]TValue, error) {
+	v := validator.NewValidator("Sort")
+	v.ValidateOptions(len(params.Options))
+
+	var options []*core.SortOption[TValue]
+	// The hints are looked up by option, as sorting reorders the options
+	hints := make(map[*core.SortOption[TValue]]string)
+	for _, option := range params.Options {
+		coreOption := &core.SortOption[TValue]{
+			Label: option.Label,
+			Value: option.Value,
+		}
+		hints[coreOption] = option.Hint
+		options = append(options, coreOption)
+	}
+
+	p := core.NewSortPrompt(core.SortPromptParams[TValue]{
+		Options:  options,
+		Validate: params.Validate,
+		Render: func(p *core.SortPrompt[TValue]) string {
+			message := params.Message
+			var value string
+
+			switch p.State {
+			case core.SubmitState, core.CancelState:
+				for _, option := range p.Options {
+					if value == "" {
+						value = option.Label
+					} else {
+						value += ", " + option.Label
+					}
+				}
+
+			default:
+				if p.IsGrabbed {
+					message = fmt.Sprintf("%s\n%s", message, picocolors.Dim("Move with up/down, drop with space"))
+				} else {
+					message = fmt.Sprintf("%s\n%s", message, picocolors.Dim("Grab with space"))
+				}
+
+				sortOptions := make([]string, len(p.Options))
+				for i, option := range p.Options {
+					if i == p.CursorIndex && p.IsGrabbed {
+						sortOptions[i] = fmt.Sprintf("%s %s", picocolors.Cyan(symbols.SORT_GRABBED), picocolors.Cyan(option.Label))
+					} else if i == p.CursorIndex {
+						sortOptions[i] = fmt.Sprintf("%s %s", picocolors.Green(symbols.RADIO_ACTIVE), option.Label)
+					} else {
+						sortOptions[i] = fmt.Sprintf("%s %s", picocolors.Dim(symbols.RADIO_INACTIVE), picocolors.Dim(option.Label))
+					}
+					if i == p.CursorIndex && hints[option] != "" {
+						sortOptions[i] += " " + picocolors.Dim("("+hints[option]+")")
+					}
+				}
+				value = p.LimitLines(sortOptions, 4)
+			}
+
+			return theme.ApplyTheme(theme.ThemeParams[[]TValue]{
+				Ctx:             p.Prompt,
+				Message:         message,
+				Value:           value,
+				ValueWithCursor: value,
+			})
+		},
+	})
+	test.SortTestingPrompt = p
+	return runPrompt(ctx, &p.Prompt, params.Name)
+}
@@ -0,0 +1,69 @@
+package prompts_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Mist3rBru/go-clack/core"
+	"github.com/Mist3rBru/go-clack/prompts"
+	"github.com/Mist3rBru/go-clack/prompts/test"
+	"github.com/bradleyjkemp/cupaloy"
+	"github.com/stretchr/testify/assert"
+)
+
+func runSort() {
+	prompts.Sort(prompts.SortParams[string]{
+		Message: message,
+		Options: []*prompts.SortOption[string]{
+			{Label: "foo", Hint: "hint-foo"},
+			{Label: "bar"},
+			{Label: "baz"},
+		},
+	})
+}
+
+func TestSortInitialState(t *testing.T) {
+	go runSort()
+	time.Sleep(time.Millisecond)
+	p := test.SortTestingPrompt.(*core.SortPrompt[string])
+
+	assert.Equal(t, core.InitialState, p.State)
+	cupaloy.SnapshotT(t, p.Frame)
+}
+
+func TestSortGrabbedState(t *testing.T) {
+	go runSort()
+	time.Sleep(time.Millisecond)
+	p := test.SortTestingPrompt.(*core.SortPrompt[string])
+
+	p.PressKey(&core.Key{Name: core.SpaceKey})
+	p.PressKey(&core.Key{Name: core.DownKey})
+
+	assert.True(t, p.IsGrabbed)
+	cupaloy.SnapshotT(t, p.Frame)
+}
+
+func TestSortSubmitState(t *testing.T) {
+	result := make(chan []string)
+	go func() {
+		value, _ := prompts.Sort(prompts.SortParams[string]{
+			Message: message,
+			Options: []*prompts.SortOption[string]{
+				{Label: "foo", Hint: "hint-foo"},
+				{Label: "bar"},
+				{Label: "baz"},
+			},
+		})
+		result <- value
+	}()
+	time.Sleep(time.Millisecond)
+	p := test.SortTestingPrompt.(*core.SortPrompt[string])
+
+	p.PressKey(&core.Key{Name: core.SpaceKey})
+	p.PressKey(&core.Key{Name: core.EndKey})
+	p.PressKey(&core.Key{Name: core.EnterKey})
+
+	assert.Equal(t, []string{"bar", "baz", "foo"}, <-result)
+	assert.Equal(t, core.SubmitState, p.State)
+	cupaloy.SnapshotT(t, p.Frame)
+}
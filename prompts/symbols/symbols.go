@@ -29,6 +29,8 @@ var (
 	CHECKBOX_ACTIVE   Symbol = s("◻", "[•]")
 	CHECKBOX_SELECTED Symbol = s("◼", "[+]")
 	CHECKBOX_INACTIVE Symbol = s("◻", "[ ]")
+	CHECKBOX_PARTIAL  Symbol = s("◩", "[-]")
+	SORT_GRABBED      Symbol = s("↕", "=")
 	PASSWORD_MASK     Symbol = s("▪", "•")
 
 	BAR_H               Symbol = s("─", "-")
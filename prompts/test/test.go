@@ -13,4 +13,11 @@ var (
 	GroupMultiSelectTestingPrompt any                         = nil
 	SelectKeyTestingPrompt        any                         = nil
 	MultiSelectPathTestingPrompt  *core.MultiSelectPathPrompt = nil
+	NumberTestingPrompt           any                         = nil
+	DateTestingPrompt             *core.DatePrompt            = nil
+	MultilineTestingPrompt        *core.MultilinePrompt       = nil
+	EditorTestingPrompt           *core.EditorPrompt          = nil
+	AutocompleteTestingPrompt     *core.AutocompletePrompt    = nil
+	AsyncSelectTestingPrompt      any                         = nil
+	SortTestingPrompt             any                         = nil
 )
@@ -1,12 +1,15 @@
 package prompts
 
 import (
+	"context"
+
 	"github.com/Mist3rBru/go-clack/core"
 	"github.com/Mist3rBru/go-clack/prompts/test"
 	"github.com/Mist3rBru/go-clack/prompts/theme"
 )
 
 type TextParams struct {
+	Name         string
 	Message      string
 	Placeholder  string
 	InitialValue string
@@ -15,6 +18,10 @@ type TextParams struct {
 }
 
 func Text(params TextParams) (string, error) {
+	return TextContext(context.Background(), params)
+}
+
+func TextContext(ctx context.Context, params TextParams) (string, error) {
 	p := core.NewTextPrompt(core.TextPromptParams{
 		InitialValue: params.InitialValue,
 		Placeholder:  params.Placeholder,
@@ -31,5 +38,5 @@ func Text(params TextParams) (string, error) {
 		},
 	})
 	test.TextTestingPrompt = p
-	return p.Run()
+	return runPrompt(ctx, &p.Prompt, params.Name)
 }
@@ -1,6 +1,7 @@
 package prompts_test
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
@@ -109,3 +110,24 @@ func TestTextSubmitState(t *testing.T) {
 	assert.Equal(t, core.SubmitState, p.State)
 	assert.Equal(t, expected, p.Frame)
 }
+
+func TestTextContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error)
+	go func() {
+		_, err := prompts.TextContext(ctx, prompts.TextParams{Message: message, InitialValue: "foo"})
+		errCh <- err
+	}()
+	time.Sleep(time.Millisecond)
+	cancel()
+	err := <-errCh
+
+	p := test.TextTestingPrompt
+	title := symbols.State(core.CancelState) + " " + message
+	value := symbols.BAR + " foo"
+	expected := strings.Join([]string{symbols.BAR, title, value, symbols.BAR}, "\r\n")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.True(t, prompts.IsCancel(err))
+	assert.Equal(t, core.CancelState, p.State)
+	assert.Equal(t, expected, p.Frame)
+}
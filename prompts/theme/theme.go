@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/Mist3rBru/go-clack/core"
+	"github.com/Mist3rBru/go-clack/core/utils"
 	"github.com/Mist3rBru/go-clack/prompts/symbols"
 	"github.com/Mist3rBru/go-clack/third_party/picocolors"
 )
@@ -40,7 +41,8 @@ func ApplyTheme[TValue ThemeValue](params ThemeParams[TValue]) string {
 
 	var valueWithCursor string
 	if params.Placeholder != "" && (params.ValueWithCursor == "" || (ctx.State == core.InitialState && params.ValueWithCursor == " ")) {
-		valueWithCursor = picocolors.Inverse(string(params.Placeholder[0])) + picocolors.Dim(params.Placeholder[1:])
+		placeholder := utils.Graphemes(params.Placeholder)
+		valueWithCursor = picocolors.Inverse(placeholder[0]) + picocolors.Dim(strings.Join(placeholder[1:], ""))
 	} else {
 		valueWithCursor = params.ValueWithCursor
 	}
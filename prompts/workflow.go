@@ -1,22 +1,43 @@
 package prompts
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
+
+	"github.com/Mist3rBru/go-clack/core"
+	"github.com/Mist3rBru/go-clack/third_party/picocolors"
 )
 
+// ErrBack is returned by a workflow step's prompt when the user goes back to the previous step.
+var ErrBack = errors.New("back to the previous step")
+
 type WorkflowStep struct {
-	Name      string
-	Prompt    func() (any, error)
-	Condition func() bool
-	SetResult bool
+	Name   string
+	Prompt func() (any, error)
+	// PromptContext is used instead of Prompt, if set, with the step's context.
+	// Prompts run with that context receive the step's answer, pre-filled value and back navigation.
+	PromptContext func(ctx context.Context) (any, error)
+	Condition     func() bool
+	SetResult     bool
+	isAction      bool
+	isFork        bool
 }
 
 type WorkflowBuilder struct {
-	steps    []*WorkflowStep
-	onCancel func(step string, err error)
-	result   any
+	steps      []*WorkflowStep
+	onCancel   func(step string, err error)
+	answers    Answers
+	checkpoint CheckpointStore
+	resume     bool
+	replay     bool
+	canGoBack  bool
+	// prefill pre-fills the steps with their result fields, for a sub-workflow entered again
+	prefill bool
+	result  any
 }
 
 // Step adds a new step to the workflow with a prompt function to gather the step's result.
@@ -28,6 +49,18 @@ func (w *WorkflowBuilder) Step(name string, prompt func() (any, error)) *Workflo
 	return w
 }
 
+// StepContext adds a new step like Step, whose prompt function receives the step's context.
+// The prompts run with that context can be answered in advance, pre-filled with the step's previous answer
+// and go back to the previous step.
+func (w *WorkflowBuilder) StepContext(name string, prompt func(ctx context.Context) (any, error)) *WorkflowBuilder {
+	w.steps = append(w.steps, &WorkflowStep{
+		Name:          name,
+		PromptContext: prompt,
+		SetResult:     true,
+	})
+	return w
+}
+
 // ConditionalStep adds a new step that runs only if the provided condition is true.
 func (w *WorkflowBuilder) ConditionalStep(name string, condition func() bool, prompt func() (any, error)) *WorkflowBuilder {
 	w.steps = append(w.steps, &WorkflowStep{
@@ -39,16 +72,51 @@ func (w *WorkflowBuilder) ConditionalStep(name string, condition func() bool, pr
 	return w
 }
 
+// ConditionalStepContext adds a new step like ConditionalStep, whose prompt function receives the step's context.
+func (w *WorkflowBuilder) ConditionalStepContext(name string, condition func() bool, prompt func(ctx context.Context) (any, error)) *WorkflowBuilder {
+	w.steps = append(w.steps, &WorkflowStep{
+		Name:          name,
+		Condition:     condition,
+		PromptContext: prompt,
+		SetResult:     true,
+	})
+	return w
+}
+
 // ForkStep adds a new step that runs a sub-workflow if the provided condition is true.
+// When it is entered again, like going back to it, the sub-workflow's steps are pre-filled with its earlier result.
 func (w *WorkflowBuilder) ForkStep(name string, condition func() bool, subWorkflow func() *WorkflowBuilder) *WorkflowBuilder {
 	w.steps = append(w.steps, &WorkflowStep{
 		Name:      name,
 		Condition: condition,
-		Prompt: func() (any, error) {
-			err := subWorkflow().Run()
-			return nil, err
+		PromptContext: func(ctx context.Context) (any, error) {
+			workflow := subWorkflow()
+			if workflow.answers == nil {
+				workflow.answers = w.answers
+			}
+			if step := stepState(ctx); step != nil {
+				workflow.canGoBack = step.canGoBack
+				workflow.replay = step.replay
+				if step.forkResult != nil {
+					if err := json.Unmarshal(step.forkResult, workflow.result); err != nil {
+						return nil, err
+					}
+				}
+				// Entered again, the sub-workflow is pre-filled with its earlier result
+				if step.hasInitialValue {
+					earlier := reflect.ValueOf(step.initialValue)
+					if result := reflect.ValueOf(workflow.result); earlier.Type() == result.Type() {
+						result.Elem().Set(earlier.Elem())
+					}
+					workflow.prefill = true
+				}
+			}
+			// The sub-workflow's result is returned to be saved in the checkpoint, as it may not be part of this result
+			err := workflow.RunContext(ctx)
+			return workflow.result, err
 		},
 		SetResult: false,
+		isFork:    true,
 	})
 	return w
 }
@@ -63,6 +131,7 @@ func (w *WorkflowBuilder) LogStep(name string, action func()) *WorkflowBuilder {
 			return nil, nil
 		},
 		SetResult: false,
+		isAction:  true,
 	})
 	return w
 }
@@ -79,46 +148,268 @@ func (w *WorkflowBuilder) OnCancel(onCancel func(step string, err error)) *Workf
 	return w
 }
 
+// Answers sets a source of pre-supplied answers, looked up by step name.
+// A step with a provided answer validates it with its prompt and shows it as submitted, without waiting for input.
+// Steps added without the step's context, like with Step, cannot pass the answer to their prompt, so it is set as is
+// if it has the field's type, or the workflow fails otherwise.
+func (w *WorkflowBuilder) Answers(answers Answers) *WorkflowBuilder {
+	w.answers = answers
+	return w
+}
+
+// Checkpoint saves the result and the completed steps to the store after each step,
+// and clears it once the workflow is done.
+func (w *WorkflowBuilder) Checkpoint(store CheckpointStore) *WorkflowBuilder {
+	w.checkpoint = store
+	return w
+}
+
+// Resume reloads the result from the store's checkpoint, if any, showing the completed steps as submitted
+// and continuing from the first unanswered step. The progress is then saved like in Checkpoint.
+func (w *WorkflowBuilder) Resume(store CheckpointStore) *WorkflowBuilder {
+	w.checkpoint = store
+	w.resume = true
+	return w
+}
+
 // Run executes all the steps in the workflow in sequence.
 // If a step's condition is not met, it is skipped.
+// Pressing Escape or Shift+Tab on a prompt run with a step's context goes back to the previous step whose condition
+// is still met, with its earlier answer pre-filled. Prompts that handle Shift+Tab themselves, like Autocomplete,
+// only go back on Escape.
 // If a step encounters an error, the onCancel callback is called and the error is returned.
 func (w *WorkflowBuilder) Run() error {
+	return w.RunContext(context.Background())
+}
+
+// RunContext executes the steps like Run, passing each step a context derived from ctx.
+func (w *WorkflowBuilder) RunContext(ctx context.Context) error {
 	v := reflect.ValueOf(w.result).Elem()
 
-	for _, step := range w.steps {
+	var history, completed []int
+	results := make(map[int]any)
+	visited := make(map[int]bool)
+	replayed, forks, err := w.loadCheckpoint()
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < len(w.steps); {
+		step := w.steps[i]
 		if step.Condition != nil && !step.Condition() {
+			i++
+			continue
+		}
+
+		isReplay := (w.replay || replayed[i]) && !visited[i]
+		if isReplay && step.isAction {
+			visited[i] = true
+			completed = append(completed, i)
+			i++
 			continue
 		}
 
-		stepResult, stepErr := step.Prompt()
+		state := &workflowStep{
+			isRevisited: visited[i],
+			canGoBack:   w.canGoBack || len(history) > 0,
+			replay:      isReplay,
+		}
+		state.initialValue, state.hasInitialValue = results[i]
+		if w.prefill && step.SetResult && !state.hasInitialValue {
+			if field := resultField(v, step); !field.IsZero() {
+				state.initialValue, state.hasInitialValue = field.Interface(), true
+			}
+		}
+		if isReplay && step.isFork {
+			state.forkResult = forks[i]
+		}
+		if isReplay && step.SetResult {
+			state.answer, state.hasAnswer = resultField(v, step).Interface(), true
+		} else if w.answers != nil && step.SetResult && !visited[i] {
+			state.answer, state.hasAnswer = w.answers.Lookup(step.Name)
+		}
+		visited[i] = true
+
+		var stepResult any
+		var stepErr error
+		if state.hasAnswer && step.PromptContext == nil {
+			// A step without the step's context cannot pass the answer to its prompt,
+			// so the saved or supplied answer is set without prompting, if it has the field's type
+			if answer, ok := plainStepAnswer(resultField(v, step).Type(), state.answer); ok {
+				stepResult = answer
+				printStepAnswer(step.Name, stepResult)
+			} else {
+				stepErr = fmt.Errorf("answer for step `%s` requires StepContext to be parsed as %s", step.Name, resultField(v, step).Type())
+			}
+		} else {
+			stepResult, stepErr = step.run(context.WithValue(ctx, workflowStepKey{}, state))
+		}
+
+		if errors.Is(stepErr, ErrBack) {
+			if prevIndex, ok := w.previousStep(&history); ok {
+				for len(completed) > 0 && completed[len(completed)-1] >= prevIndex {
+					completed = completed[:len(completed)-1]
+				}
+				i = prevIndex
+				continue
+			}
+			if w.canGoBack {
+				return stepErr
+			}
+			continue
+		}
 		if stepErr != nil {
 			if w.onCancel != nil {
 				w.onCancel(step.Name, stepErr)
 			}
 			return stepErr
 		}
-		if !step.SetResult {
-			continue
+		if !step.isAction {
+			history = append(history, i)
 		}
-
-		field := v.FieldByName(capitalize(step.Name))
-		if !field.IsValid() {
-			panic(fmt.Sprintf("workflow error: invalid field `%s`", step.Name))
+		if step.SetResult {
+			w.setResult(v, step, stepResult)
 		}
-		if !field.CanSet() {
-			panic(fmt.Sprintf("workflow error: cannot set field `%s`", step.Name))
+		if step.SetResult || step.isFork {
+			results[i] = stepResult
 		}
-
-		stepResultVal := reflect.ValueOf(stepResult)
-		if !stepResultVal.Type().AssignableTo(field.Type()) {
-			panic(fmt.Sprintf("workflow error: type `%s` is not assignable to field `%s`", stepResultVal.Type(), step.Name))
+		completed = append(completed, i)
+		if step.isFork && w.checkpoint != nil {
+			if forks[i], err = json.Marshal(stepResult); err != nil {
+				return err
+			}
 		}
+		if err := w.saveCheckpoint(completed, forks); err != nil {
+			return err
+		}
+		i++
+	}
 
-		field.Set(stepResultVal)
+	if w.checkpoint != nil {
+		return w.checkpoint.Clear()
 	}
 	return nil
 }
 
+// run runs the step's prompt function, with the step's context if it accepts one.
+func (step *WorkflowStep) run(ctx context.Context) (any, error) {
+	if step.PromptContext != nil {
+		return step.PromptContext(ctx)
+	}
+	return step.Prompt()
+}
+
+// plainStepAnswer converts an answer to the type of a step's result field, for steps whose prompt cannot parse it.
+// Only answers assignable to the field, or lists of assignable items, are converted.
+func plainStepAnswer(t reflect.Type, answer any) (any, bool) {
+	value := reflect.ValueOf(answer)
+	switch {
+	case !value.IsValid():
+		return nil, false
+	case value.Type().AssignableTo(t):
+		return answer, true
+	case value.Kind() == reflect.Slice && t.Kind() == reflect.Slice:
+		items := reflect.MakeSlice(t, 0, value.Len())
+		for i := range value.Len() {
+			item, ok := plainStepAnswer(t.Elem(), value.Index(i).Interface())
+			if !ok {
+				return nil, false
+			}
+			items = reflect.Append(items, reflect.ValueOf(item))
+		}
+		return items.Interface(), true
+	}
+	return nil, false
+}
+
+// printStepAnswer shows the answer of a step that was not prompted, as a submitted step.
+func printStepAnswer(name string, answer any) {
+	value := fmt.Sprint(answer)
+	if v := reflect.ValueOf(answer); v.Kind() == reflect.Slice {
+		items := make([]string, v.Len())
+		for i := range v.Len() {
+			items[i] = fmt.Sprint(v.Index(i).Interface())
+		}
+		value = strings.Join(items, ", ")
+	}
+	Step(fmt.Sprintf("%s\n%s", name, picocolors.Dim(value)))
+}
+
+// loadCheckpoint restores the result from the checkpoint when resuming,
+// returning the completed steps and the results of the completed sub-workflows.
+func (w *WorkflowBuilder) loadCheckpoint() (map[int]bool, map[int]json.RawMessage, error) {
+	replayed := make(map[int]bool)
+	forks := make(map[int]json.RawMessage)
+	if !w.resume || w.checkpoint == nil {
+		return replayed, forks, nil
+	}
+
+	checkpoint, err := w.checkpoint.Load()
+	if err != nil || checkpoint == nil {
+		return replayed, forks, err
+	}
+	if err := json.Unmarshal(checkpoint.Result, w.result); err != nil {
+		return replayed, forks, err
+	}
+	for _, i := range checkpoint.Completed {
+		replayed[i] = true
+	}
+	for i, result := range checkpoint.Forks {
+		forks[i] = result
+	}
+	return replayed, forks, nil
+}
+
+// saveCheckpoint saves the result, the completed steps and the results of the completed sub-workflows,
+// if there is a checkpoint store.
+func (w *WorkflowBuilder) saveCheckpoint(completed []int, forks map[int]json.RawMessage) error {
+	if w.checkpoint == nil {
+		return nil
+	}
+
+	result, err := json.Marshal(w.result)
+	if err != nil {
+		return err
+	}
+	return w.checkpoint.Save(&Checkpoint{Result: result, Completed: completed, Forks: forks})
+}
+
+// setResult sets the result field named after the step.
+func (w *WorkflowBuilder) setResult(v reflect.Value, step *WorkflowStep, stepResult any) {
+	field := resultField(v, step)
+	if !field.CanSet() {
+		panic(fmt.Sprintf("workflow error: cannot set field `%s`", step.Name))
+	}
+
+	stepResultVal := reflect.ValueOf(stepResult)
+	if !stepResultVal.Type().AssignableTo(field.Type()) {
+		panic(fmt.Sprintf("workflow error: type `%s` is not assignable to field `%s`", stepResultVal.Type(), step.Name))
+	}
+
+	field.Set(stepResultVal)
+}
+
+// resultField returns the result field named after the step.
+func resultField(v reflect.Value, step *WorkflowStep) reflect.Value {
+	field := v.FieldByName(capitalize(step.Name))
+	if !field.IsValid() {
+		panic(fmt.Sprintf("workflow error: invalid field `%s`", step.Name))
+	}
+	return field
+}
+
+// previousStep pops the history until a step whose condition is still met.
+func (w *WorkflowBuilder) previousStep(history *[]int) (int, bool) {
+	for len(*history) > 0 {
+		i := (*history)[len(*history)-1]
+		*history = (*history)[:len(*history)-1]
+		if step := w.steps[i]; step.Condition == nil || step.Condition() {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
 func Workflow(v any) *WorkflowBuilder {
 	return &WorkflowBuilder{result: v}
 }
@@ -129,3 +420,29 @@ func capitalize(str string) string {
 	}
 	return strings.ToUpper(string(str[0])) + (str[1:])
 }
+
+// workflowStep holds the state of a running workflow step, carried by the step's context.
+type workflowStep struct {
+	answer          any
+	hasAnswer       bool
+	initialValue    any
+	hasInitialValue bool
+	isRevisited     bool
+	canGoBack       bool
+	replay          bool
+	// forkResult is the saved result of a replayed fork's sub-workflow
+	forkResult json.RawMessage
+}
+
+type workflowStepKey struct{}
+
+// stepState returns the state of the workflow step whose context is ctx, if any.
+func stepState(ctx context.Context) *workflowStep {
+	step, _ := ctx.Value(workflowStepKey{}).(*workflowStep)
+	return step
+}
+
+// isBackKey reports whether the key goes back to the previous workflow step.
+func isBackKey(key *core.Key) bool {
+	return key.Name == core.EscapeKey || (key.Name == core.TabKey && key.Shift)
+}
@@ -1,10 +1,15 @@
 package prompts_test
 
 import (
+	"context"
 	"errors"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/Mist3rBru/go-clack/core"
 	"github.com/Mist3rBru/go-clack/prompts"
+	"github.com/Mist3rBru/go-clack/prompts/test"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -168,3 +173,369 @@ func TestWorkflowCaseInsensitive(t *testing.T) {
 	assert.Equal(t, "test name", r.Name)
 	assert.Equal(t, 22, r.Age)
 }
+
+func TestWorkflowBack(t *testing.T) {
+	var r struct {
+		Project string
+		Tools   []string
+		Install bool
+	}
+	var calls []string
+	projects := []string{"Node", "Go"}
+
+	err := prompts.Workflow(&r).
+		Step("Project", func() (any, error) {
+			calls = append(calls, "Project")
+			project := projects[0]
+			projects = projects[1:]
+			return project, nil
+		}).
+		ConditionalStep("Tools",
+			func() bool { return r.Project == "Node" },
+			func() (any, error) {
+				calls = append(calls, "Tools")
+				return []string{"Eslint"}, nil
+			},
+		).
+		LogStep("Log", func() {
+			calls = append(calls, "Log")
+		}).
+		Step("Install", func() (any, error) {
+			calls = append(calls, "Install")
+			if len(calls) == 4 {
+				return nil, prompts.ErrBack
+			}
+			if len(calls) == 7 {
+				r.Project = ""
+				return nil, prompts.ErrBack
+			}
+			return true, nil
+		}).
+		Run()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Project", "Tools", "Log", "Install", "Tools", "Log", "Install", "Project", "Log", "Install"}, calls)
+	assert.Equal(t, "Go", r.Project)
+	assert.Equal(t, true, r.Install)
+}
+
+func waitTextPrompt(t *testing.T, prev *core.TextPrompt) *core.TextPrompt {
+	assert.Eventually(t, func() bool {
+		p := test.TextTestingPrompt
+		return p != prev && p.Frame != ""
+	}, time.Second, time.Millisecond)
+	return test.TextTestingPrompt
+}
+
+func TestWorkflowBackWithPrompts(t *testing.T) {
+	var r struct {
+		Name string
+		Age  string
+	}
+	done := make(chan error)
+	prev := test.TextTestingPrompt
+
+	go func() {
+		done <- prompts.Workflow(&r).
+			StepContext("Name", func(ctx context.Context) (any, error) {
+				return prompts.TextContext(ctx, prompts.TextParams{Message: "Name"})
+			}).
+			StepContext("Age", func(ctx context.Context) (any, error) {
+				return prompts.TextContext(ctx, prompts.TextParams{Message: "Age"})
+			}).
+			Run()
+	}()
+
+	p := waitTextPrompt(t, prev)
+	p.PressKey(&core.Key{Name: core.EscapeKey})
+	p.PressKey(&core.Key{Char: "f"})
+	p.PressKey(&core.Key{Name: core.EnterKey})
+
+	p = waitTextPrompt(t, p)
+	p.PressKey(&core.Key{Char: "1"})
+	p.PressKey(&core.Key{Name: core.TabKey, Shift: true})
+
+	p = waitTextPrompt(t, p)
+	assert.Equal(t, "f", p.Value)
+	p.PressKey(&core.Key{Char: "o"})
+	p.PressKey(&core.Key{Name: core.EnterKey})
+
+	p = waitTextPrompt(t, p)
+	p.PressKey(&core.Key{Char: "2"})
+	p.PressKey(&core.Key{Name: core.EnterKey})
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		assert.FailNow(t, "workflow did not finish")
+	}
+	assert.Equal(t, "fo", r.Name)
+	assert.Equal(t, "2", r.Age)
+}
+
+func TestWorkflowBackWithPathPrompt(t *testing.T) {
+	var r struct {
+		Name string
+		Dir  string
+	}
+	done := make(chan error)
+	prev := test.TextTestingPrompt
+	test.PathTestingPrompt = nil
+
+	go func() {
+		done <- prompts.Workflow(&r).
+			StepContext("Name", func(ctx context.Context) (any, error) {
+				return prompts.TextContext(ctx, prompts.TextParams{Message: "Name", InitialValue: "foo"})
+			}).
+			StepContext("Dir", func(ctx context.Context) (any, error) {
+				return prompts.PathContext(ctx, prompts.PathParams{Message: "Dir", InitialValue: "/"})
+			}).
+			Run()
+	}()
+
+	p := waitTextPrompt(t, prev)
+	p.PressKey(&core.Key{Name: core.EnterKey})
+	assert.Eventually(t, func() bool { return test.PathTestingPrompt != nil }, time.Second, time.Millisecond)
+	path := test.PathTestingPrompt
+
+	// Shift+Tab goes back before the prompt handles it as a tab completion
+	path.PressKey(&core.Key{Name: core.TabKey, Shift: true})
+	assert.Equal(t, core.CancelState, path.State)
+	assert.Empty(t, path.HintOptions)
+
+	waitTextPrompt(t, p).PressKey(&core.Key{Name: core.EnterKey})
+	assert.Eventually(t, func() bool { return test.PathTestingPrompt != path }, time.Second, time.Millisecond)
+	test.PathTestingPrompt.PressKey(&core.Key{Name: core.EnterKey})
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		assert.FailNow(t, "workflow did not finish")
+	}
+	assert.Equal(t, "foo", r.Name)
+	assert.Equal(t, "/", r.Dir)
+}
+
+func TestFileCheckpoint(t *testing.T) {
+	store := prompts.FileCheckpoint(filepath.Join(t.TempDir(), "checkpoint.json"))
+
+	checkpoint, err := store.Load()
+	assert.NoError(t, err)
+	assert.Nil(t, checkpoint)
+
+	err = store.Save(&prompts.Checkpoint{Result: []byte(`{"Name":"foo"}`), Completed: []int{0}})
+	assert.NoError(t, err)
+
+	checkpoint, err = store.Load()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"Name":"foo"}`, string(checkpoint.Result))
+	assert.Equal(t, []int{0}, checkpoint.Completed)
+
+	assert.NoError(t, store.Clear())
+	checkpoint, err = store.Load()
+	assert.NoError(t, err)
+	assert.Nil(t, checkpoint)
+}
+
+func TestWorkflowResume(t *testing.T) {
+	type Result struct {
+		Name    string
+		Install bool
+		Age     int
+	}
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	store := prompts.FileCheckpoint(path)
+
+	var r Result
+	var logCalls int
+	err := prompts.Workflow(&r).
+		Checkpoint(store).
+		Step("Name", func() (any, error) {
+			return "foo", nil
+		}).
+		LogStep("Log", func() {
+			logCalls++
+		}).
+		Step("Install", func() (any, error) {
+			return true, nil
+		}).
+		Step("Age", func() (any, error) {
+			return 0, core.ErrCancelPrompt
+		}).
+		Run()
+	assert.ErrorIs(t, err, core.ErrCancelPrompt)
+	assert.FileExists(t, path)
+
+	var resumed Result
+	err = prompts.Workflow(&resumed).
+		Resume(store).
+		StepContext("Name", func(ctx context.Context) (any, error) {
+			return prompts.TextContext(ctx, prompts.TextParams{Message: "Name"})
+		}).
+		LogStep("Log", func() {
+			logCalls++
+		}).
+		StepContext("Install", func(ctx context.Context) (any, error) {
+			return prompts.ConfirmContext(ctx, prompts.ConfirmParams{Message: "Install"})
+		}).
+		Step("Age", func() (any, error) {
+			return 22, nil
+		}).
+		Run()
+	assert.NoError(t, err)
+	assert.Equal(t, Result{Name: "foo", Install: true, Age: 22}, resumed)
+	assert.Equal(t, 1, logCalls)
+	assert.NoFileExists(t, path)
+}
+
+func TestWorkflowResumeWithFork(t *testing.T) {
+	type Database struct {
+		Host string
+	}
+	type Result struct {
+		Name string
+		Age  int
+	}
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	store := prompts.FileCheckpoint(path)
+
+	var r Result
+	var db Database
+	err := prompts.Workflow(&r).
+		Checkpoint(store).
+		Step("Name", func() (any, error) {
+			return "foo", nil
+		}).
+		ForkStep("Database", func() bool { return true }, func() *prompts.WorkflowBuilder {
+			return prompts.Workflow(&db).
+				Step("Host", func() (any, error) {
+					return "localhost", nil
+				})
+		}).
+		Step("Age", func() (any, error) {
+			return 0, core.ErrCancelPrompt
+		}).
+		Run()
+	assert.ErrorIs(t, err, core.ErrCancelPrompt)
+
+	var resumed Result
+	var resumedDB Database
+	err = prompts.Workflow(&resumed).
+		Resume(store).
+		StepContext("Name", func(ctx context.Context) (any, error) {
+			return prompts.TextContext(ctx, prompts.TextParams{Message: "Name"})
+		}).
+		ForkStep("Database", func() bool { return true }, func() *prompts.WorkflowBuilder {
+			return prompts.Workflow(&resumedDB).
+				StepContext("Host", func(ctx context.Context) (any, error) {
+					return prompts.TextContext(ctx, prompts.TextParams{Message: "Host"})
+				})
+		}).
+		Step("Age", func() (any, error) {
+			return 22, nil
+		}).
+		Run()
+	assert.NoError(t, err)
+	assert.Equal(t, Result{Name: "foo", Age: 22}, resumed)
+	assert.Equal(t, Database{Host: "localhost"}, resumedDB)
+	assert.NoFileExists(t, path)
+}
+
+func TestWorkflowResumeWithPlainSteps(t *testing.T) {
+	type Result struct {
+		Name string
+		Age  int
+	}
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	store := prompts.FileCheckpoint(path)
+
+	var r Result
+	err := prompts.Workflow(&r).
+		Checkpoint(store).
+		Step("Name", func() (any, error) {
+			return "foo", nil
+		}).
+		Step("Age", func() (any, error) {
+			return 0, core.ErrCancelPrompt
+		}).
+		Run()
+	assert.ErrorIs(t, err, core.ErrCancelPrompt)
+
+	var resumed Result
+	var nameCalls int
+	err = prompts.Workflow(&resumed).
+		Resume(store).
+		Step("Name", func() (any, error) {
+			nameCalls++
+			return "bar", nil
+		}).
+		Step("Age", func() (any, error) {
+			return 22, nil
+		}).
+		Run()
+	assert.NoError(t, err)
+	assert.Equal(t, Result{Name: "foo", Age: 22}, resumed)
+	assert.Equal(t, 0, nameCalls)
+	assert.NoFileExists(t, path)
+}
+
+func TestWorkflowBackIntoForkStep(t *testing.T) {
+	type Database struct {
+		Host string
+	}
+	var r struct {
+		Name string
+		Port string
+	}
+	done := make(chan error)
+	prev := test.TextTestingPrompt
+
+	go func() {
+		done <- prompts.Workflow(&r).
+			StepContext("Name", func(ctx context.Context) (any, error) {
+				return prompts.TextContext(ctx, prompts.TextParams{Message: "Name"})
+			}).
+			ForkStep("Database", func() bool { return true }, func() *prompts.WorkflowBuilder {
+				var db Database
+				return prompts.Workflow(&db).
+					StepContext("Host", func(ctx context.Context) (any, error) {
+						return prompts.TextContext(ctx, prompts.TextParams{Message: "Host"})
+					})
+			}).
+			StepContext("Port", func(ctx context.Context) (any, error) {
+				return prompts.TextContext(ctx, prompts.TextParams{Message: "Port"})
+			}).
+			Run()
+	}()
+
+	p := waitTextPrompt(t, prev)
+	p.PressKey(&core.Key{Char: "f"})
+	p.PressKey(&core.Key{Name: core.EnterKey})
+
+	p = waitTextPrompt(t, p)
+	p.PressKey(&core.Key{Char: "h"})
+	p.PressKey(&core.Key{Name: core.EnterKey})
+
+	p = waitTextPrompt(t, p)
+	p.PressKey(&core.Key{Name: core.EscapeKey})
+
+	// The sub-workflow is built again, with a new result, pre-filled with the earlier answer
+	p = waitTextPrompt(t, p)
+	assert.Equal(t, "h", p.Value)
+	p.PressKey(&core.Key{Name: core.EnterKey})
+
+	p = waitTextPrompt(t, p)
+	p.PressKey(&core.Key{Char: "1"})
+	p.PressKey(&core.Key{Name: core.EnterKey})
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		assert.FailNow(t, "workflow did not finish")
+	}
+	assert.Equal(t, "f", r.Name)
+	assert.Equal(t, "1", r.Port)
+}
@@ -55,3 +55,11 @@ func EraseCurrentLine() string {
 func EraseDown() string {
 	return "\x1b[J"
 }
+
+func EnableBracketedPaste() string {
+	return fmt.Sprintf("%s?2004h", CSI)
+}
+
+func DisableBracketedPaste() string {
+	return fmt.Sprintf("%s?2004l", CSI)
+}